@@ -0,0 +1,109 @@
+// Package branches wraps the git plumbing behind gitix's Branches submenu:
+// listing, checking out, creating, deleting, merging, renaming, and setting
+// the upstream of local branches.
+package branches
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// Branch describes one local branch as reported by `git branch --list`.
+type Branch struct {
+	Name     string
+	Upstream string
+	Track    string // e.g. "ahead 2, behind 1", "gone", or "" if up to date
+	Commit   string
+	Subject  string
+	IsHead   bool
+}
+
+// branchFormat mirrors the column order ListBranches parses: HEAD marker,
+// branch name, upstream, ahead/behind tracking status, short commit,
+// subject.
+const branchFormat = "%(HEAD)%(refname:short)|%(upstream:short)|%(upstream:track)|%(objectname:short)|%(subject)"
+
+// ListBranches returns every local branch in the current repo.
+func ListBranches() ([]Branch, error) {
+	cmd := exec.Command("git", "branch", "--list", "--format="+branchFormat)
+	var out, stderr bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("git branch --list: %w: %s", err, stderr.String())
+	}
+
+	var result []Branch
+	for _, line := range strings.Split(strings.TrimRight(out.String(), "\n"), "\n") {
+		if line == "" {
+			continue
+		}
+		// %(HEAD) is always one byte wide — "*" for the current branch,
+		// a single space for every other one — so it must be trimmed
+		// unconditionally rather than matched against "*", or every
+		// non-current branch's Name ends up with a leading space.
+		isHead := line[0] == '*'
+		line = line[1:]
+		parts := strings.SplitN(line, "|", 5)
+		if len(parts) != 5 {
+			continue
+		}
+		result = append(result, Branch{
+			Name:     parts[0],
+			Upstream: parts[1],
+			Track:    strings.Trim(parts[2], "[]"),
+			Commit:   parts[3],
+			Subject:  parts[4],
+			IsHead:   isHead,
+		})
+	}
+	return result, nil
+}
+
+// Checkout switches the working tree to the given branch.
+func Checkout(name string) error {
+	return run("checkout", name)
+}
+
+// Create creates a new branch with the given name, pointed at the current
+// HEAD, and switches to it.
+func Create(name string) error {
+	return run("checkout", "-b", name)
+}
+
+// Delete removes a local branch. When force is true, it deletes the branch
+// even if it has unmerged changes (git branch -D instead of -d).
+func Delete(name string, force bool) error {
+	flag := "-d"
+	if force {
+		flag = "-D"
+	}
+	return run("branch", flag, name)
+}
+
+// Merge merges the given branch into the current branch.
+func Merge(name string) error {
+	return run("merge", name)
+}
+
+// Rename renames a local branch from oldName to newName.
+func Rename(oldName, newName string) error {
+	return run("branch", "-m", oldName, newName)
+}
+
+// SetUpstream sets the upstream tracking branch for the given local branch.
+func SetUpstream(name, upstream string) error {
+	return run("branch", "--set-upstream-to="+upstream, name)
+}
+
+func run(args ...string) error {
+	cmd := exec.Command("git", args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("git %s: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
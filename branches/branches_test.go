@@ -0,0 +1,61 @@
+package branches
+
+import (
+	"os"
+	"os/exec"
+	"testing"
+)
+
+// TestListBranchesTrimsHeadMarker reproduces the maintainer's report: the
+// %(HEAD) field is one byte wide for every branch (a literal space for
+// anything but the checked-out one), so it must always be trimmed rather
+// than only when it's "*" — otherwise every non-current branch's Name
+// comes back with a leading space and every action taken on it fails.
+func TestListBranchesTrimsHeadMarker(t *testing.T) {
+	dir := t.TempDir()
+
+	run := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+	}
+	run("init", "-q")
+	run("config", "user.email", "test@example.com")
+	run("config", "user.name", "Test")
+	if err := os.WriteFile(dir+"/f.txt", []byte("line1\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	run("add", "f.txt")
+	run("commit", "-q", "-m", "init")
+	run("branch", "feature")
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	branches, err := ListBranches()
+	if err != nil {
+		t.Fatalf("ListBranches: %v", err)
+	}
+
+	var feature *Branch
+	for i := range branches {
+		if branches[i].Name == "feature" {
+			feature = &branches[i]
+		}
+	}
+	if feature == nil {
+		t.Fatalf("no branch named exactly %q found in %+v (leading space not trimmed?)", "feature", branches)
+	}
+	if feature.IsHead {
+		t.Fatalf("feature branch should not be HEAD")
+	}
+}
@@ -0,0 +1,48 @@
+package branches
+
+import (
+	"github.com/rivo/tview"
+
+	"github.com/NeonTowel/gitix/diff"
+)
+
+// contextKey is the Context identifier registered with the ContextManager,
+// distinct from the submenu's "Branches (Work Areas)" label.
+const contextKey = "Branches"
+
+// Context adapts the branches table to the gui/context.Context interface so
+// it can be pushed onto the ContextManager's stack when the user opens
+// "Show Branches" from the submenu, and popped back to the submenu on Esc.
+type Context struct {
+	container   *tview.Flex
+	app         *tview.Application
+	actionPanel *tview.TextView
+	table       tview.Primitive
+	onCancel    func()
+	mode        *diff.Mode
+	onConflict  func()
+}
+
+// NewContext builds a branches Context rendering into container. mode may
+// be nil if the caller doesn't want diff-mode row markers wired up.
+// onConflict is called when merging a branch leaves conflict markers
+// behind, so the caller can push the merge-conflicts panel.
+func NewContext(container *tview.Flex, app *tview.Application, actionPanel *tview.TextView, onCancel func(), mode *diff.Mode, onConflict func()) *Context {
+	return &Context{container: container, app: app, actionPanel: actionPanel, onCancel: onCancel, mode: mode, onConflict: onConflict}
+}
+
+func (c *Context) GetKey() string { return contextKey }
+
+func (c *Context) HandleFocus() error {
+	if c.table != nil {
+		c.app.SetFocus(c.table)
+	}
+	return nil
+}
+
+func (c *Context) HandleFocusLost() error { return nil }
+
+func (c *Context) HandleRender() error {
+	c.table = ShowBranchesUI(c.container, c.app, c.actionPanel, c.onCancel, c.mode, c.onConflict)
+	return nil
+}
@@ -0,0 +1,176 @@
+package branches
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/NeonTowel/gitix/diff"
+	"github.com/NeonTowel/gitix/mergeconflicts"
+)
+
+// ShowBranchesUI renders the branches table into container and wires up its
+// keybindings. It mirrors save.ShowSaveUI's shape so both panels can be
+// driven the same way from main.go: build into the container, report errors
+// and progress through actionPanel, and call onCancel when the user backs
+// out with Esc. mode may be nil; when set, rows whose tip commit is diff
+// mode's current base/target are annotated with diff.BaseMarker/
+// TargetMarker. Branches doesn't reuse d/D to pick endpoints here the way
+// commits does, since 'd' is already bound to branch deletion. onConflict
+// is called instead of showing the raw error when a merge (M) leaves
+// conflict markers behind, so the caller can push the merge-conflicts
+// panel on top of this one.
+func ShowBranchesUI(container *tview.Flex, app *tview.Application, actionPanel *tview.TextView, onCancel func(), mode *diff.Mode, onConflict func()) tview.Primitive {
+	table := tview.NewTable().SetSelectable(true, false).SetFixed(1, 0)
+	table.SetBorder(true).SetTitle("Branches")
+
+	var rows []Branch
+
+	render := func() {
+		branches, err := ListBranches()
+		if err != nil {
+			actionPanel.SetText("Error listing branches: " + err.Error())
+			return
+		}
+		rows = branches
+
+		table.Clear()
+		headers := []string{"", "Branch", "Upstream", "Ahead/Behind", "Commit", "Subject"}
+		for col, h := range headers {
+			table.SetCell(0, col, tview.NewTableCell(h).
+				SetSelectable(false).
+				SetTextColor(tcell.ColorYellow))
+		}
+		for i, b := range rows {
+			marker := " "
+			if b.IsHead {
+				marker = "*"
+			}
+			name := b.Name
+			if mode != nil {
+				if m := mode.Marker(b.Commit); m != "" {
+					name = m + " " + name
+				}
+			}
+			table.SetCell(i+1, 0, tview.NewTableCell(marker))
+			table.SetCell(i+1, 1, tview.NewTableCell(name))
+			table.SetCell(i+1, 2, tview.NewTableCell(b.Upstream))
+			table.SetCell(i+1, 3, tview.NewTableCell(b.Track))
+			table.SetCell(i+1, 4, tview.NewTableCell(b.Commit))
+			table.SetCell(i+1, 5, tview.NewTableCell(b.Subject))
+		}
+		if len(rows) > 0 {
+			table.Select(1, 0)
+		}
+	}
+	render()
+
+	selected := func() (Branch, bool) {
+		row, _ := table.GetSelection()
+		index := row - 1
+		if index < 0 || index >= len(rows) {
+			return Branch{}, false
+		}
+		return rows[index], true
+	}
+
+	runAndRefresh := func(action func(Branch) error) {
+		branch, ok := selected()
+		if !ok {
+			return
+		}
+		if err := action(branch); err != nil {
+			actionPanel.SetText(err.Error())
+			return
+		}
+		actionPanel.SetText("")
+		render()
+	}
+
+	cancelFunc := func() {
+		container.Clear()
+		actionPanel.SetText("")
+		if onCancel != nil {
+			onCancel()
+		}
+	}
+
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		branch, hasSelection := selected()
+		switch event.Key() {
+		case tcell.KeyEsc:
+			cancelFunc()
+			return nil
+		case tcell.KeyRune:
+			switch event.Rune() {
+			case ' ':
+				runAndRefresh(func(b Branch) error { return Checkout(b.Name) })
+				return nil
+			case 'n':
+				promptForName(container, app, table, actionPanel, "New branch name: ", func(name string) {
+					runAndRefresh(func(Branch) error { return Create(name) })
+				})
+				return nil
+			case 'd':
+				if hasSelection && !branch.IsHead {
+					runAndRefresh(func(b Branch) error { return Delete(b.Name, false) })
+				}
+				return nil
+			case 'M':
+				if hasSelection && !branch.IsHead {
+					if err := Merge(branch.Name); err != nil {
+						if has, _ := mergeconflicts.HasConflicts(); has && onConflict != nil {
+							onConflict()
+							return nil
+						}
+						actionPanel.SetText(err.Error())
+						return nil
+					}
+					actionPanel.SetText("")
+					render()
+				}
+				return nil
+			case 'r':
+				if hasSelection {
+					promptForName(container, app, table, actionPanel, "New name for "+branch.Name+": ", func(name string) {
+						runAndRefresh(func(b Branch) error { return Rename(b.Name, name) })
+					})
+				}
+				return nil
+			case 'u':
+				if hasSelection {
+					promptForName(container, app, table, actionPanel, "Upstream for "+branch.Name+": ", func(upstream string) {
+						runAndRefresh(func(b Branch) error { return SetUpstream(b.Name, upstream) })
+					})
+				}
+				return nil
+			}
+		}
+		return event
+	})
+
+	container.Clear()
+	container.AddItem(table, 0, 1, true)
+	return table
+}
+
+// promptForName temporarily swaps container for a single-line input field,
+// calling onSubmit with the entered text and restoring the table afterwards.
+func promptForName(container *tview.Flex, app *tview.Application, table *tview.Table, actionPanel *tview.TextView, label string, onSubmit func(string)) {
+	input := tview.NewInputField().SetLabel(label)
+	restore := func() {
+		container.Clear()
+		container.AddItem(table, 0, 1, true)
+		app.SetFocus(table)
+	}
+	input.SetDoneFunc(func(key tcell.Key) {
+		text := input.GetText()
+		restore()
+		if key == tcell.KeyEnter && text != "" {
+			onSubmit(text)
+		}
+	})
+	container.Clear()
+	container.AddItem(table, 0, 1, false)
+	container.AddItem(input, 1, 0, true)
+	app.SetFocus(input)
+}
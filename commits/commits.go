@@ -0,0 +1,116 @@
+// Package commits implements gitix's history panel: loading the commit log
+// from git, rendering it with an ASCII commit graph, and range operations
+// (checkout, revert, reset, cherry-pick) on the selected commit.
+package commits
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/rivo/tview"
+)
+
+// Commit is one entry in the history panel.
+type Commit struct {
+	Hash      string
+	Parents   []string
+	Author    string
+	Timestamp int64
+	Subject   string
+	Graph     string // ASCII graph column, filled in by BuildGraph
+}
+
+// logFormat mirrors the column order LoadCommits parses: short hash,
+// parent hashes, author name, unix timestamp, subject.
+const logFormat = "%h|%p|%an|%at|%s"
+
+// LoadCommits returns up to limit commits reachable from HEAD, most recent
+// first. When filterPath is non-empty, only commits touching that path are
+// returned.
+func LoadCommits(limit int, filterPath string) ([]Commit, error) {
+	args := []string{"log", "--oneline", "--decorate", "--pretty=format:" + logFormat, "-n", strconv.Itoa(limit)}
+	if filterPath != "" {
+		args = append(args, "--", filterPath)
+	}
+
+	cmd := exec.Command("git", args...)
+	var out, stderr bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("git log: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	var result []Commit
+	for _, line := range strings.Split(strings.TrimRight(out.String(), "\n"), "\n") {
+		if line == "" {
+			continue
+		}
+		parts := strings.SplitN(line, "|", 5)
+		if len(parts) != 5 {
+			continue
+		}
+		ts, _ := strconv.ParseInt(parts[3], 10, 64)
+		var parents []string
+		if parts[1] != "" {
+			parents = strings.Fields(parts[1])
+		}
+		result = append(result, Commit{
+			Hash:      parts[0],
+			Parents:   parents,
+			Author:    parts[2],
+			Timestamp: ts,
+			Subject:   parts[4],
+		})
+	}
+	BuildGraph(result)
+	return result, nil
+}
+
+// Checkout checks out the given commit, detaching HEAD.
+func Checkout(hash string) error {
+	return run("checkout", hash)
+}
+
+// Revert creates a new commit that undoes the given commit.
+func Revert(hash string) error {
+	return run("revert", "--no-edit", hash)
+}
+
+// ResetHard resets the current branch to the given commit, discarding
+// everything after it.
+func ResetHard(hash string) error {
+	return run("reset", "--hard", hash)
+}
+
+// CherryPick applies the given commit on top of the current branch.
+func CherryPick(hash string) error {
+	return run("cherry-pick", hash)
+}
+
+// Show runs `git show --color` for the given commit and returns it
+// translated into tview colour tags, ready for TextView.SetText(text, true)
+// with dynamic colours enabled.
+func Show(hash string) (string, error) {
+	cmd := exec.Command("git", "show", "--color", hash)
+	var out, stderr bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("git show: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return tview.TranslateANSI(out.String()), nil
+}
+
+func run(args ...string) error {
+	cmd := exec.Command("git", args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("git %s: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package commits
+
+import (
+	"github.com/rivo/tview"
+
+	"github.com/NeonTowel/gitix/diff"
+)
+
+// contextKey is the Context identifier registered with the ContextManager,
+// distinct from the submenu's "Save Changes" label it's opened from.
+const contextKey = "History"
+
+// Context adapts the commits table to the gui/context.Context interface so
+// it can be pushed onto the ContextManager's stack from "View History" or
+// "Search History" (which land on the same panel — pressing / starts the
+// fuzzy filter), and popped back to the submenu on Esc.
+type Context struct {
+	container   *tview.Flex
+	app         *tview.Application
+	actionPanel *tview.TextView
+	table       tview.Primitive
+	onCancel    func()
+	mode        *diff.Mode
+}
+
+// NewContext builds a commits Context rendering into container. mode may be
+// nil if the caller doesn't want diff-mode base/target picking wired up.
+func NewContext(container *tview.Flex, app *tview.Application, actionPanel *tview.TextView, onCancel func(), mode *diff.Mode) *Context {
+	return &Context{container: container, app: app, actionPanel: actionPanel, onCancel: onCancel, mode: mode}
+}
+
+func (c *Context) GetKey() string { return contextKey }
+
+func (c *Context) HandleFocus() error {
+	if c.table != nil {
+		c.app.SetFocus(c.table)
+	}
+	return nil
+}
+
+func (c *Context) HandleFocusLost() error { return nil }
+
+func (c *Context) HandleRender() error {
+	c.table = ShowCommitsUI(c.container, c.app, c.actionPanel, c.onCancel, c.mode)
+	return nil
+}
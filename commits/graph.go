@@ -0,0 +1,105 @@
+package commits
+
+import "strings"
+
+// Graph glyphs, matching the style lazygit's presentation/graph package uses
+// for continuing, merging, and branching lanes.
+const (
+	glyphVertical     = "│" // │ lane continues
+	glyphHorizontal   = "─" // ─ lane shifts sideways
+	glyphBranchDown   = "╭" // ╭ new lane branches off to the right
+	glyphBranchUp     = "╮" // ╮ new lane branches off from the right
+	glyphMergeDown    = "╯" // ╯ a lane merges in from the right
+	glyphMergeUp      = "╰" // ╰ a lane merges in from the left
+	glyphCommit       = "*"
+)
+
+// BuildGraph computes an ASCII commit graph for commits (ordered newest
+// first, as returned by `git log`) and fills in each Commit's Graph field.
+//
+// It keeps an ordered slice of "lanes", each holding the SHA of the commit
+// still expected on that lane. For every commit: find the lane carrying its
+// SHA, emit a glyph per lane (vertical for lanes untouched this row,
+// commit/merge/branch glyphs for the lane(s) involved), then replace that
+// lane with the commit's first parent and append any additional parents as
+// new lanes to the right.
+func BuildGraph(commits []Commit) {
+	var lanes []string
+
+	for i := range commits {
+		c := &commits[i]
+		lane := indexOf(lanes, c.Hash)
+		if lane == -1 {
+			// Commit wasn't expected on any lane (e.g. the first commit, or
+			// a root of a filtered log) — give it a fresh lane of its own.
+			lanes = append(lanes, c.Hash)
+			lane = len(lanes) - 1
+		}
+
+		cols := make([]string, len(lanes))
+		for col := range lanes {
+			switch {
+			case col == lane:
+				cols[col] = glyphCommit
+			case col < lane:
+				cols[col] = glyphVertical
+			default:
+				cols[col] = " "
+			}
+		}
+
+		if len(c.Parents) == 0 {
+			lanes = removeAt(lanes, lane)
+			c.Graph = strings.Join(cols, "")
+			continue
+		}
+
+		lanes[lane] = c.Parents[0]
+		for _, parent := range c.Parents[1:] {
+			if merge := indexOf(lanes, parent); merge != -1 {
+				// This parent already has a lane elsewhere: the two lanes
+				// converge here. Draw the horizontal run between them,
+				// closing off with a merge glyph at the far end.
+				markRun(cols, lane, merge, glyphMergeDown, glyphMergeUp)
+				continue
+			}
+			// A genuinely new parent: open a lane for it to the right,
+			// marking the branch point between the commit and the new lane.
+			lanes = append(lanes, parent)
+			cols = append(cols, " ")
+			markRun(cols, lane, len(lanes)-1, glyphBranchDown, glyphBranchUp)
+		}
+		c.Graph = strings.Join(cols, "")
+	}
+}
+
+// markRun fills the columns strictly between from and to with a horizontal
+// glyph and marks the far end with endGlyph, used for both merges (an
+// existing lane rejoining) and branches (a new lane peeling off).
+func markRun(cols []string, from, to int, endGlyphRight, endGlyphLeft string) {
+	lo, hi, endGlyph := from, to, endGlyphRight
+	if to < from {
+		lo, hi, endGlyph = to, from, endGlyphLeft
+	}
+	for col := lo + 1; col < hi; col++ {
+		if cols[col] == "" || cols[col] == " " {
+			cols[col] = glyphHorizontal
+		}
+	}
+	if cols[to] == "" || cols[to] == " " || cols[to] == glyphVertical {
+		cols[to] = endGlyph
+	}
+}
+
+func indexOf(lanes []string, hash string) int {
+	for i, l := range lanes {
+		if l == hash {
+			return i
+		}
+	}
+	return -1
+}
+
+func removeAt(lanes []string, i int) []string {
+	return append(lanes[:i], lanes[i+1:]...)
+}
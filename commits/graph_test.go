@@ -0,0 +1,43 @@
+package commits
+
+import "testing"
+
+// TestBuildGraphLinear checks the simple case: a straight line of commits,
+// each with exactly one parent, stays on a single lane.
+func TestBuildGraphLinear(t *testing.T) {
+	commits := []Commit{
+		{Hash: "c", Parents: []string{"b"}},
+		{Hash: "b", Parents: []string{"a"}},
+		{Hash: "a", Parents: nil},
+	}
+	BuildGraph(commits)
+
+	for i, c := range commits {
+		if c.Graph != "*" {
+			t.Fatalf("commit %d (%s): graph = %q, want %q", i, c.Hash, c.Graph, "*")
+		}
+	}
+}
+
+// TestBuildGraphMerge checks that a merge commit's second parent opens a new
+// lane to the right, that the second parent's commit is drawn on that lane,
+// and that the first parent's commit is drawn back on the main lane.
+func TestBuildGraphMerge(t *testing.T) {
+	commits := []Commit{
+		{Hash: "merge", Parents: []string{"main2", "feature"}},
+		{Hash: "feature", Parents: []string{"main1"}},
+		{Hash: "main2", Parents: []string{"main1"}},
+		{Hash: "main1", Parents: nil},
+	}
+	BuildGraph(commits)
+
+	if commits[0].Graph != "*╭" {
+		t.Fatalf("merge commit graph = %q, want %q", commits[0].Graph, "*╭")
+	}
+	if commits[1].Graph != "│*" {
+		t.Fatalf("feature commit graph = %q, want %q", commits[1].Graph, "│*")
+	}
+	if commits[2].Graph != "* " {
+		t.Fatalf("main2 commit graph = %q, want %q", commits[2].Graph, "* ")
+	}
+}
@@ -0,0 +1,77 @@
+package commits
+
+import "strings"
+
+// trieNode is one node of the suffix trie (one node per byte, not
+// path-compressed) SearchIndex builds over
+// commit subjects and author names, letting "Search History" filter
+// incrementally as the user types instead of rescanning every commit on
+// each keystroke.
+type trieNode struct {
+	children map[byte]*trieNode
+	commits  map[int]bool // indexes into SearchIndex.commits reachable from here
+}
+
+func newTrieNode() *trieNode {
+	return &trieNode{children: map[byte]*trieNode{}, commits: map[int]bool{}}
+}
+
+// SearchIndex is an in-memory fuzzy index over a slice of commits, built
+// once and queried on every keystroke of the search box.
+type SearchIndex struct {
+	commits []Commit
+	root    *trieNode
+}
+
+// NewSearchIndex indexes every suffix of each commit's subject and author,
+// so a query matches anywhere in either string, not just at the start.
+func NewSearchIndex(commits []Commit) *SearchIndex {
+	idx := &SearchIndex{commits: commits, root: newTrieNode()}
+	for i, c := range commits {
+		idx.indexWord(strings.ToLower(c.Subject), i)
+		idx.indexWord(strings.ToLower(c.Author), i)
+	}
+	return idx
+}
+
+func (idx *SearchIndex) indexWord(word string, commitIndex int) {
+	for start := 0; start < len(word); start++ {
+		node := idx.root
+		for j := start; j < len(word); j++ {
+			b := word[j]
+			child, ok := node.children[b]
+			if !ok {
+				child = newTrieNode()
+				node.children[b] = child
+			}
+			node = child
+			node.commits[commitIndex] = true
+		}
+	}
+}
+
+// Query returns the commits whose subject or author contains term,
+// case-insensitively, in the order they appear in the indexed slice.
+func (idx *SearchIndex) Query(term string) []Commit {
+	term = strings.ToLower(term)
+	if term == "" {
+		return idx.commits
+	}
+
+	node := idx.root
+	for i := 0; i < len(term); i++ {
+		child, ok := node.children[term[i]]
+		if !ok {
+			return nil
+		}
+		node = child
+	}
+
+	var results []Commit
+	for i := range idx.commits {
+		if node.commits[i] {
+			results = append(results, idx.commits[i])
+		}
+	}
+	return results
+}
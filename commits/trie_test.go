@@ -0,0 +1,40 @@
+package commits
+
+import "testing"
+
+func TestSearchIndexQuery(t *testing.T) {
+	commits := []Commit{
+		{Hash: "aaa", Subject: "Add login form", Author: "Alice"},
+		{Hash: "bbb", Subject: "Fix logout bug", Author: "Bob"},
+		{Hash: "ccc", Subject: "Update README", Author: "Alice"},
+	}
+	idx := NewSearchIndex(commits)
+
+	t.Run("matches subject substring", func(t *testing.T) {
+		got := idx.Query("log")
+		if len(got) != 2 || got[0].Hash != "aaa" || got[1].Hash != "bbb" {
+			t.Fatalf("Query(\"log\") = %+v, want aaa and bbb", got)
+		}
+	})
+
+	t.Run("matches author, case-insensitively", func(t *testing.T) {
+		got := idx.Query("ALICE")
+		if len(got) != 2 || got[0].Hash != "aaa" || got[1].Hash != "ccc" {
+			t.Fatalf("Query(\"ALICE\") = %+v, want aaa and ccc", got)
+		}
+	})
+
+	t.Run("empty term returns everything", func(t *testing.T) {
+		got := idx.Query("")
+		if len(got) != len(commits) {
+			t.Fatalf("Query(\"\") returned %d commits, want %d", len(got), len(commits))
+		}
+	})
+
+	t.Run("no match returns nothing", func(t *testing.T) {
+		got := idx.Query("xyz")
+		if got != nil {
+			t.Fatalf("Query(\"xyz\") = %+v, want nil", got)
+		}
+	})
+}
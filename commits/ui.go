@@ -0,0 +1,253 @@
+package commits
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/NeonTowel/gitix/diff"
+)
+
+// historyLimit bounds how many commits LoadCommits pulls per render; the
+// user can narrow further with the fuzzy filter.
+const historyLimit = 500
+
+// cherryPickMarker prefixes the subject of a commit marked with s for the
+// next batch cherry-pick (applied with S).
+const cherryPickMarker = "»"
+
+// ShowCommitsUI renders the commit history table into container and wires up
+// its keybindings. It mirrors save.ShowSaveUI and branches.ShowBranchesUI's
+// shape: build into the container, report progress through actionPanel, and
+// call onCancel when the user backs out with Esc. When mode is non-nil, d/D
+// pick the selected commit as diff mode's base/target and matching rows are
+// annotated with diff.BaseMarker/TargetMarker. s toggles the selected commit
+// for a batch cherry-pick (marked rows get cherryPickMarker) and S applies
+// every marked commit, oldest first, the way R is ResetHard to r's Revert:
+// the capital letter is the batch/drastic counterpart of the lowercase one.
+func ShowCommitsUI(container *tview.Flex, app *tview.Application, actionPanel *tview.TextView, onCancel func(), mode *diff.Mode) tview.Primitive {
+	table := tview.NewTable().SetSelectable(true, false).SetFixed(0, 0)
+	table.SetBorder(true).SetTitle("History")
+
+	var all []Commit
+	var index *SearchIndex
+	var rows []Commit
+	marked := map[string]bool{}
+
+	renderRows := func() {
+		table.Clear()
+		for i, c := range rows {
+			subject := c.Subject
+			if marked[c.Hash] {
+				subject = cherryPickMarker + " " + subject
+			}
+			if mode != nil {
+				if marker := mode.Marker(c.Hash); marker != "" {
+					subject = marker + " " + subject
+				}
+			}
+			table.SetCell(i, 0, tview.NewTableCell(c.Graph))
+			table.SetCell(i, 1, tview.NewTableCell(c.Hash).SetTextColor(tcell.ColorYellow))
+			table.SetCell(i, 2, tview.NewTableCell(c.Author))
+			table.SetCell(i, 3, tview.NewTableCell(relativeTime(c.Timestamp)))
+			table.SetCell(i, 4, tview.NewTableCell(subject))
+		}
+		if len(rows) > 0 {
+			table.Select(0, 0)
+		}
+	}
+
+	load := func() {
+		commits, err := LoadCommits(historyLimit, "")
+		if err != nil {
+			actionPanel.SetText("Error loading history: " + err.Error())
+			return
+		}
+		all = commits
+		index = NewSearchIndex(all)
+		rows = all
+		renderRows()
+	}
+	load()
+
+	selected := func() (Commit, bool) {
+		row, _ := table.GetSelection()
+		if row < 0 || row >= len(rows) {
+			return Commit{}, false
+		}
+		return rows[row], true
+	}
+
+	runAndReload := func(action func(Commit) error) {
+		commit, ok := selected()
+		if !ok {
+			return
+		}
+		if err := action(commit); err != nil {
+			actionPanel.SetText(err.Error())
+			return
+		}
+		actionPanel.SetText("")
+		load()
+	}
+
+	cancelFunc := func() {
+		container.Clear()
+		actionPanel.SetText("")
+		if onCancel != nil {
+			onCancel()
+		}
+	}
+
+	// applyCherryPicks cherry-picks every marked commit, oldest first (all is
+	// newest-first, matching git log), so the batch lands in the order it was
+	// originally committed.
+	applyCherryPicks := func() {
+		if len(marked) == 0 {
+			return
+		}
+		for i := len(all) - 1; i >= 0; i-- {
+			if hash := all[i].Hash; marked[hash] {
+				if err := CherryPick(hash); err != nil {
+					actionPanel.SetText(err.Error())
+					return
+				}
+				delete(marked, hash)
+			}
+		}
+		actionPanel.SetText("")
+		load()
+	}
+
+	startFuzzyFilter := func() {
+		input := tview.NewInputField().SetLabel("Search: ")
+		input.SetChangedFunc(func(text string) {
+			rows = index.Query(text)
+			renderRows()
+		})
+		restore := func() {
+			container.Clear()
+			container.AddItem(table, 0, 1, true)
+			app.SetFocus(table)
+		}
+		input.SetDoneFunc(func(key tcell.Key) {
+			restore()
+		})
+		container.Clear()
+		container.AddItem(table, 0, 1, false)
+		container.AddItem(input, 1, 0, true)
+		app.SetFocus(input)
+	}
+
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEsc:
+			cancelFunc()
+			return nil
+		case tcell.KeyEnter:
+			if commit, ok := selected(); ok {
+				text, err := Show(commit.Hash)
+				if err != nil {
+					actionPanel.SetText(err.Error())
+					return nil
+				}
+				actionPanel.SetText(text)
+			}
+			return nil
+		case tcell.KeyRune:
+			switch event.Rune() {
+			case 'c':
+				runAndReload(func(c Commit) error { return Checkout(c.Hash) })
+				return nil
+			case 'r':
+				runAndReload(func(c Commit) error { return Revert(c.Hash) })
+				return nil
+			case 'R':
+				runAndReload(func(c Commit) error { return ResetHard(c.Hash) })
+				return nil
+			case 's':
+				if commit, ok := selected(); ok {
+					if marked[commit.Hash] {
+						delete(marked, commit.Hash)
+					} else {
+						marked[commit.Hash] = true
+					}
+					renderRows()
+				}
+				return nil
+			case 'S':
+				applyCherryPicks()
+				return nil
+			case '/':
+				startFuzzyFilter()
+				return nil
+			case 'd':
+				if mode != nil {
+					if commit, ok := selected(); ok {
+						mode.SetBase(commit.Hash)
+						renderRows()
+						showDiffIfReady(mode, actionPanel)
+					}
+				}
+				return nil
+			case 'D':
+				if mode != nil {
+					if commit, ok := selected(); ok {
+						mode.SetTarget(commit.Hash)
+						renderRows()
+						showDiffIfReady(mode, actionPanel)
+					}
+				}
+				return nil
+			}
+		}
+		return event
+	})
+
+	container.Clear()
+	container.AddItem(table, 0, 1, true)
+	return table
+}
+
+// showDiffIfReady renders the diff between mode's endpoints into actionPanel
+// once both are picked; until then it's a no-op, leaving the panel showing
+// whatever it last showed.
+func showDiffIfReady(mode *diff.Mode, actionPanel *tview.TextView) {
+	if mode.BaseRef == "" || mode.TargetRef == "" {
+		return
+	}
+	text, err := mode.RenderText()
+	if err != nil {
+		actionPanel.SetText(err.Error())
+		return
+	}
+	actionPanel.SetText(text)
+}
+
+// relativeTime renders a unix timestamp the way lazygit's commit list does,
+// e.g. "3 hours ago", falling back to an absolute date once it's old enough
+// that "ago" stops being useful.
+func relativeTime(unix int64) string {
+	d := time.Since(time.Unix(unix, 0))
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return pluralize(int(d/time.Minute), "minute") + " ago"
+	case d < 24*time.Hour:
+		return pluralize(int(d/time.Hour), "hour") + " ago"
+	case d < 30*24*time.Hour:
+		return pluralize(int(d/(24*time.Hour)), "day") + " ago"
+	default:
+		return time.Unix(unix, 0).Format("2006-01-02")
+	}
+}
+
+func pluralize(n int, unit string) string {
+	if n == 1 {
+		return "1 " + unit
+	}
+	return strconv.Itoa(n) + " " + unit + "s"
+}
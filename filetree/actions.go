@@ -0,0 +1,39 @@
+package filetree
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/NeonTowel/gitix/save"
+)
+
+// StageNode stages every file under n in a single `git add`, so staging a
+// directory doesn't shell out once per descendant.
+func StageNode(n *Node) error {
+	return run(append([]string{"add"}, paths(n.Files())...)...)
+}
+
+// UnstageNode unstages every file under n in a single `git reset`.
+func UnstageNode(n *Node) error {
+	return run(append([]string{"reset", "--"}, paths(n.Files())...)...)
+}
+
+func paths(files []save.File) []string {
+	result := make([]string, len(files))
+	for i, f := range files {
+		result[i] = f.Path
+	}
+	return result
+}
+
+func run(args ...string) error {
+	cmd := exec.Command("git", args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("git %s: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package filetree
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ViewMode selects how the Changed Files panel renders: as a flat list or
+// as a directory tree.
+type ViewMode string
+
+const (
+	ViewFlat ViewMode = "flat"
+	ViewTree ViewMode = "tree"
+)
+
+// Config is gitix's on-disk settings, persisted under
+// $XDG_CONFIG_HOME/gitix/config.yml (falling back to ~/.config).
+type Config struct {
+	View ViewMode
+}
+
+func configPath() (string, error) {
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		dir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(dir, "gitix", "config.yml"), nil
+}
+
+// LoadConfig reads gitix's config file, defaulting to ViewFlat if the file
+// doesn't exist or has no "view" key.
+func LoadConfig() (Config, error) {
+	cfg := Config{View: ViewFlat}
+
+	path, err := configPath()
+	if err != nil {
+		return cfg, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return cfg, nil
+	}
+	if err != nil {
+		return cfg, err
+	}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		if strings.TrimSpace(key) == "view" {
+			cfg.View = ViewMode(strings.TrimSpace(value))
+		}
+	}
+	return cfg, nil
+}
+
+// SaveConfig writes cfg to gitix's config file, creating its directory if
+// needed. The file is a minimal hand-written "key: value" format rather
+// than a full YAML document, since it only ever holds a handful of scalar
+// settings.
+func SaveConfig(cfg Config) error {
+	path, err := configPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(fmt.Sprintf("view: %s\n", cfg.View)), 0o644)
+}
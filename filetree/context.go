@@ -0,0 +1,41 @@
+package filetree
+
+import (
+	"github.com/rivo/tview"
+)
+
+// contextKey is the Context identifier registered with the ContextManager.
+const contextKey = "Changed Files"
+
+// Context adapts the Changed Files view (flat list or tree, per the user's
+// remembered preference) to the gui/context.Context interface so it can be
+// pushed from "Check Files" > "Show Changes" and popped back to the submenu
+// on Esc.
+type Context struct {
+	container   *tview.Flex
+	app         *tview.Application
+	actionPanel *tview.TextView
+	view        tview.Primitive
+	onCancel    func()
+}
+
+// NewContext builds a filetree Context rendering into container.
+func NewContext(container *tview.Flex, app *tview.Application, actionPanel *tview.TextView, onCancel func()) *Context {
+	return &Context{container: container, app: app, actionPanel: actionPanel, onCancel: onCancel}
+}
+
+func (c *Context) GetKey() string { return contextKey }
+
+func (c *Context) HandleFocus() error {
+	if c.view != nil {
+		c.app.SetFocus(c.view)
+	}
+	return nil
+}
+
+func (c *Context) HandleFocusLost() error { return nil }
+
+func (c *Context) HandleRender() error {
+	c.view = ShowFileTreeUI(c.container, c.app, c.actionPanel, c.onCancel)
+	return nil
+}
@@ -0,0 +1,115 @@
+// Package filetree turns the flat list of changed files from pkg/save into
+// a directory tree for gitix's Changed Files view, with collapse/expand and
+// directory-level staging.
+package filetree
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/NeonTowel/gitix/save"
+)
+
+// Node is one entry in the file tree: either a directory (Children set,
+// File nil) or a changed file (File set, Children nil).
+type Node struct {
+	Name     string
+	Path     string
+	Children []*Node
+	File     *save.File
+	Expanded bool
+}
+
+// IsDir reports whether n is a directory node.
+func (n *Node) IsDir() bool {
+	return n.File == nil
+}
+
+// Files returns every changed file reachable from n, recursing into
+// children. For a file node it returns itself.
+func (n *Node) Files() []save.File {
+	if !n.IsDir() {
+		return []save.File{*n.File}
+	}
+	var files []save.File
+	for _, child := range n.Children {
+		files = append(files, child.Files()...)
+	}
+	return files
+}
+
+// BuildTree splits each file's path on "/" and builds the directory tree,
+// collapsing any run of directories that have exactly one child into a
+// single node (so "a/b/c/foo.go" renders as "a/b/c/" when nothing else
+// lives under a or b).
+func BuildTree(files []save.File) *Node {
+	root := &Node{Name: "", Path: "", Expanded: true}
+	for i := range files {
+		insert(root, files[i], strings.Split(files[i].Path, "/"))
+	}
+	sortTree(root)
+	for _, child := range root.Children {
+		collapseSingleChildDirs(child)
+	}
+	return root
+}
+
+func insert(parent *Node, file save.File, segments []string) {
+	name := segments[0]
+	if len(segments) == 1 {
+		path := name
+		if parent.Path != "" {
+			path = parent.Path + "/" + name
+		}
+		parent.Children = append(parent.Children, &Node{Name: name, Path: path, File: &file})
+		return
+	}
+
+	var dir *Node
+	for _, child := range parent.Children {
+		if child.IsDir() && child.Name == name {
+			dir = child
+			break
+		}
+	}
+	if dir == nil {
+		path := name
+		if parent.Path != "" {
+			path = parent.Path + "/" + name
+		}
+		dir = &Node{Name: name, Path: path, Expanded: true}
+		parent.Children = append(parent.Children, dir)
+	}
+	insert(dir, file, segments[1:])
+}
+
+func sortTree(n *Node) {
+	sort.Slice(n.Children, func(i, j int) bool {
+		a, b := n.Children[i], n.Children[j]
+		if a.IsDir() != b.IsDir() {
+			return a.IsDir() // directories before files, like most file trees
+		}
+		return a.Name < b.Name
+	})
+	for _, child := range n.Children {
+		sortTree(child)
+	}
+}
+
+// collapseSingleChildDirs merges a directory with its single directory
+// child into one node (e.g. "a" -> "b" becomes "a/b"), recursively.
+func collapseSingleChildDirs(n *Node) {
+	for _, child := range n.Children {
+		collapseSingleChildDirs(child)
+	}
+	for len(n.Children) == 1 && n.Children[0].IsDir() {
+		only := n.Children[0]
+		if n.Path == "" {
+			n.Name = only.Name
+		} else {
+			n.Name = n.Name + "/" + only.Name
+		}
+		n.Path = only.Path
+		n.Children = only.Children
+	}
+}
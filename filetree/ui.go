@@ -0,0 +1,127 @@
+package filetree
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/NeonTowel/gitix/save"
+)
+
+// ShowFileTreeUI renders the changed-files tree (or, per the user's
+// remembered preference, a flat list) into container. It mirrors
+// save.ShowSaveUI's shape: build into the container, report errors through
+// actionPanel, and call onCancel when the user backs out with Esc.
+func ShowFileTreeUI(container *tview.Flex, app *tview.Application, actionPanel *tview.TextView, onCancel func()) tview.Primitive {
+	cfg, err := LoadConfig()
+	if err != nil {
+		actionPanel.SetText("Error loading gitix config: " + err.Error())
+		cfg = Config{View: ViewFlat}
+	}
+
+	var primitive tview.Primitive
+	var load func()
+
+	flatList := tview.NewList().ShowSecondaryText(false)
+	flatList.SetBorder(true).SetTitle("Changed Files")
+
+	onStage := func(n *Node) error { return StageNode(n) }
+	onUnstage := func(n *Node) error { return UnstageNode(n) }
+
+	var tree *FileTreeView
+	switchMode := func(mode ViewMode) {
+		cfg.View = mode
+		if err := SaveConfig(cfg); err != nil {
+			actionPanel.SetText("Error saving gitix config: " + err.Error())
+		}
+		load()
+	}
+
+	toggleView := func() {
+		if cfg.View == ViewTree {
+			switchMode(ViewFlat)
+		} else {
+			switchMode(ViewTree)
+		}
+	}
+
+	tree = NewFileTreeView(onStage, onUnstage, func(s string) { actionPanel.SetText(s) },
+		toggleView,
+		func(bool) { load() },
+		func() { load() },
+	)
+
+	renderFlat := func(files []save.File) {
+		flatList.Clear()
+		for _, f := range files {
+			file := f
+			label := file.Path
+			flatList.AddItem(label, "", 0, func() {
+				action := onStage
+				if file.StagedStatus != ' ' && file.StagedStatus != '?' {
+					action = onUnstage
+				}
+				if err := action(&Node{File: &file, Children: nil}); err != nil {
+					actionPanel.SetText(err.Error())
+					return
+				}
+				load()
+			})
+		}
+		container.Clear()
+		container.AddItem(flatList, 0, 1, true)
+		primitive = flatList
+		app.SetFocus(flatList)
+	}
+
+	renderTree := func(files []save.File) {
+		tree.SetRoot(BuildTree(files))
+		container.Clear()
+		container.AddItem(tree, 0, 1, true)
+		primitive = tree
+		app.SetFocus(tree)
+	}
+
+	load = func() {
+		files, err := save.GetChangedFiles()
+		if err != nil {
+			actionPanel.SetText("Error getting changed files: " + err.Error())
+			return
+		}
+		if cfg.View == ViewTree {
+			renderTree(files)
+		} else {
+			renderFlat(files)
+		}
+	}
+	load()
+
+	cancelFunc := func() {
+		container.Clear()
+		actionPanel.SetText("")
+		if onCancel != nil {
+			onCancel()
+		}
+	}
+
+	wrapEsc := func(p tview.Primitive) {
+		if box, ok := p.(interface {
+			SetInputCapture(func(*tcell.EventKey) *tcell.EventKey) *tview.Box
+		}); ok {
+			box.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+				if event.Key() == tcell.KeyEsc {
+					cancelFunc()
+					return nil
+				}
+				if event.Key() == tcell.KeyRune && event.Rune() == '`' {
+					toggleView()
+					return nil
+				}
+				return event
+			})
+		}
+	}
+	wrapEsc(flatList)
+	wrapEsc(tree)
+
+	return primitive
+}
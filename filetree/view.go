@@ -0,0 +1,208 @@
+package filetree
+
+import (
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// flatRow is one visible line of the tree, flattened depth-first so the
+// cursor can move through it with Up/Down regardless of nesting.
+type flatRow struct {
+	node  *Node
+	depth int
+}
+
+// FileTreeView renders a Node tree (as built by BuildTree) with
+// collapse/expand and directory-level staging.
+type FileTreeView struct {
+	*tview.Box
+
+	root   *Node
+	rows   []flatRow
+	cursor int
+
+	showIgnored   bool
+	onStage       func(*Node) error
+	onUnstage     func(*Node) error
+	onMessage     func(string)
+	onToggled     func()
+	onShowIgnored func(bool)
+	onChanged     func()
+}
+
+// NewFileTreeView creates an empty tree view. onStage/onUnstage receive the
+// node under the cursor (a single file, or every descendant of a
+// directory); onToggled fires whenever flat/tree mode should flip,
+// onShowIgnored fires with the new state whenever '.' is pressed, and
+// onChanged fires after a successful stage/unstage — all three let the
+// caller decide how to re-render. This view backs the "Check Files" >
+// "Show Changes" panel (filetree.ShowFileTreeUI) only: save.ShowSaveUI
+// can't build on it without save importing filetree, which already
+// imports save for save.File, so ShowSaveUI keeps its own flat
+// tview.List for picking a file to open in the hunk-level StagingPanel.
+func NewFileTreeView(onStage, onUnstage func(*Node) error, onMessage func(string), onToggled func(), onShowIgnored func(bool), onChanged func()) *FileTreeView {
+	return &FileTreeView{
+		Box:           tview.NewBox().SetBorder(true).SetTitle("Changed Files"),
+		onStage:       onStage,
+		onUnstage:     onUnstage,
+		onMessage:     onMessage,
+		onToggled:     onToggled,
+		onShowIgnored: onShowIgnored,
+		onChanged:     onChanged,
+	}
+}
+
+// ShowIgnored reports whether the user has toggled showing ignored/untracked
+// files with '.'. The caller is responsible for re-fetching the file list
+// with that in mind and calling SetRoot again.
+func (v *FileTreeView) ShowIgnored() bool {
+	return v.showIgnored
+}
+
+// SetRoot loads a new tree into the view.
+func (v *FileTreeView) SetRoot(root *Node) {
+	v.root = root
+	v.cursor = 0
+	v.flatten()
+}
+
+func (v *FileTreeView) flatten() {
+	v.rows = nil
+	if v.root == nil {
+		return
+	}
+	var walk func(n *Node, depth int)
+	walk = func(n *Node, depth int) {
+		for _, child := range n.Children {
+			v.rows = append(v.rows, flatRow{node: child, depth: depth})
+			if child.IsDir() && child.Expanded {
+				walk(child, depth+1)
+			}
+		}
+	}
+	walk(v.root, 0)
+}
+
+func (v *FileTreeView) Draw(screen tcell.Screen) {
+	v.Box.DrawForSubclass(screen, v)
+	x, y, width, height := v.GetInnerRect()
+
+	for i, row := range v.rows {
+		if i >= height {
+			break
+		}
+		label := row.node.Name
+		if row.node.IsDir() {
+			marker := "▸"
+			if row.node.Expanded {
+				marker = "▾"
+			}
+			label = marker + " " + label + "/"
+		}
+		line := strings.Repeat("  ", row.depth) + label
+
+		style := tcell.StyleDefault.Foreground(tcell.ColorWhite)
+		if i == v.cursor {
+			style = style.Reverse(true)
+		}
+
+		col := x
+		for _, r := range line {
+			if col >= x+width {
+				break
+			}
+			screen.SetContent(col, y+i, r, nil, style)
+			col++
+		}
+	}
+}
+
+func (v *FileTreeView) InputHandler() func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+	return v.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+		switch event.Key() {
+		case tcell.KeyUp:
+			v.moveCursor(-1)
+		case tcell.KeyDown:
+			v.moveCursor(1)
+		case tcell.KeyEnter:
+			v.toggleExpand()
+		case tcell.KeyRune:
+			switch event.Rune() {
+			case ' ':
+				v.toggleStage()
+			case '`':
+				if v.onToggled != nil {
+					v.onToggled()
+				}
+			case '.':
+				v.showIgnored = !v.showIgnored
+				if v.onShowIgnored != nil {
+					v.onShowIgnored(v.showIgnored)
+				}
+			}
+		}
+	})
+}
+
+func (v *FileTreeView) moveCursor(delta int) {
+	if len(v.rows) == 0 {
+		return
+	}
+	v.cursor += delta
+	if v.cursor < 0 {
+		v.cursor = 0
+	}
+	if v.cursor >= len(v.rows) {
+		v.cursor = len(v.rows) - 1
+	}
+}
+
+func (v *FileTreeView) toggleExpand() {
+	if len(v.rows) == 0 {
+		return
+	}
+	node := v.rows[v.cursor].node
+	if !node.IsDir() {
+		return
+	}
+	node.Expanded = !node.Expanded
+	v.flatten()
+}
+
+// toggleStage stages or unstages every file under the cursor's node,
+// recursing into a directory's descendants in one call. A node whose files
+// are all staged is unstaged; otherwise it is staged.
+func (v *FileTreeView) toggleStage() {
+	if len(v.rows) == 0 {
+		return
+	}
+	node := v.rows[v.cursor].node
+
+	// A directory counts as staged only once every file under it is, so
+	// Space on a partly staged directory stages the rest.
+	files := node.Files()
+	staged := len(files) > 0
+	for _, f := range files {
+		if f.StagedStatus == ' ' || f.StagedStatus == '?' {
+			staged = false
+			break
+		}
+	}
+	var err error
+	if staged {
+		err = v.onUnstage(node)
+	} else {
+		err = v.onStage(node)
+	}
+	if err != nil {
+		if v.onMessage != nil {
+			v.onMessage(err.Error())
+		}
+		return
+	}
+	if v.onChanged != nil {
+		v.onChanged()
+	}
+}
@@ -0,0 +1,32 @@
+// Package context defines the Context/Manager architecture that drives
+// focus, panel visibility, and render lifecycle for gitix's panels. It
+// replaces the ad-hoc switch-casing that used to live in main.go for
+// swapping between top-level submenus: every panel (Save, Branches,
+// Commits, ...) registers itself as a Context and the Manager takes care of
+// stacking it, focusing its primitive, and re-rendering it. Per-key
+// shortcuts within a panel (Space=checkout, d=delete, /=fuzzy filter, ...)
+// are still each panel's own responsibility, wired directly via
+// tview.Primitive.SetInputCapture — most of them depend on selection state
+// or temporarily swap focus to a prompt, which doesn't fit a flat key→handler
+// list cleanly, so there's no central keybinding dispatch here.
+package context
+
+// Context is a focusable panel in the gitix UI. Packages that own a panel
+// (pkg/save, pkg/branches, pkg/commits, ...) implement Context and register
+// it with a context.Manager instead of being switch-cased on by name in
+// main.go.
+type Context interface {
+	// GetKey returns the context's unique identifier, used to register it
+	// with a Manager and to look up the primitive it should focus.
+	GetKey() string
+
+	// HandleFocus runs when this context becomes the active one.
+	HandleFocus() error
+
+	// HandleFocusLost runs when another context replaces this one as active.
+	HandleFocusLost() error
+
+	// HandleRender (re)draws the context's panel, e.g. after a mutating
+	// action such as staging a file or creating a branch.
+	HandleRender() error
+}
@@ -0,0 +1,40 @@
+package context
+
+// ListContext adapts a simple, list-backed submenu to the Context interface
+// so it can be registered with a Manager. It's the common case: a panel that
+// has nothing more to do on focus/render than show itself in the content
+// area. Panels with real lifecycle needs (e.g. pkg/branches refreshing from
+// git on every render) implement Context directly instead.
+type ListContext struct {
+	Key         string
+	OnFocus     func() error
+	OnFocusLost func() error
+	OnRender    func() error
+}
+
+// GetKey returns the context's unique identifier.
+func (c *ListContext) GetKey() string { return c.Key }
+
+// HandleFocus runs OnFocus if set.
+func (c *ListContext) HandleFocus() error {
+	if c.OnFocus == nil {
+		return nil
+	}
+	return c.OnFocus()
+}
+
+// HandleFocusLost runs OnFocusLost if set.
+func (c *ListContext) HandleFocusLost() error {
+	if c.OnFocusLost == nil {
+		return nil
+	}
+	return c.OnFocusLost()
+}
+
+// HandleRender runs OnRender if set.
+func (c *ListContext) HandleRender() error {
+	if c.OnRender == nil {
+		return nil
+	}
+	return c.OnRender()
+}
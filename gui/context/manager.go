@@ -0,0 +1,95 @@
+package context
+
+import "github.com/rivo/tview"
+
+// Manager owns the stack of active contexts and drives tview focus and panel
+// visibility on their behalf. It does not write to the action panel itself;
+// each context's render does that. Pushing a context makes it active;
+// popping restores whatever was active before it. Top-level submenus replace
+// the whole stack via SetRoot, while panels that sit on top of a submenu
+// (e.g. the branches table opened from Branches (Work Areas)) are pushed and
+// popped without disturbing what's underneath.
+type Manager struct {
+	app         *tview.Application
+	actionPanel *tview.TextView
+	stack       []Context
+	primitives  map[string]tview.Primitive
+}
+
+// NewManager creates a Manager with an empty context stack.
+func NewManager(app *tview.Application, actionPanel *tview.TextView) *Manager {
+	return &Manager{
+		app:         app,
+		actionPanel: actionPanel,
+		primitives:  map[string]tview.Primitive{},
+	}
+}
+
+// Register associates a context with the primitive the Manager should focus
+// whenever that context becomes active.
+func (m *Manager) Register(ctx Context, primitive tview.Primitive) {
+	m.primitives[ctx.GetKey()] = primitive
+}
+
+// Push makes ctx the active context: the current top (if any) loses focus,
+// ctx renders, and tview focus moves to its registered primitive.
+func (m *Manager) Push(ctx Context) error {
+	if top := m.Top(); top != nil {
+		if err := top.HandleFocusLost(); err != nil {
+			return err
+		}
+	}
+	m.stack = append(m.stack, ctx)
+	return m.focusTop()
+}
+
+// Pop removes the active context and restores focus to whatever is now on
+// top of the stack, if anything.
+func (m *Manager) Pop() error {
+	if len(m.stack) == 0 {
+		return nil
+	}
+	top := m.stack[len(m.stack)-1]
+	m.stack = m.stack[:len(m.stack)-1]
+	if err := top.HandleFocusLost(); err != nil {
+		return err
+	}
+	return m.focusTop()
+}
+
+// SetRoot clears the context stack and pushes ctx as the new base context.
+// Used when switching between top-level submenus, where the new context
+// isn't layered on top of the old one but replaces it outright.
+func (m *Manager) SetRoot(ctx Context) error {
+	for len(m.stack) > 0 {
+		if err := m.Pop(); err != nil {
+			return err
+		}
+	}
+	return m.Push(ctx)
+}
+
+// Top returns the active context, or nil if the stack is empty.
+func (m *Manager) Top() Context {
+	if len(m.stack) == 0 {
+		return nil
+	}
+	return m.stack[len(m.stack)-1]
+}
+
+func (m *Manager) focusTop() error {
+	top := m.Top()
+	if top == nil {
+		return nil
+	}
+	if err := top.HandleRender(); err != nil {
+		return err
+	}
+	if err := top.HandleFocus(); err != nil {
+		return err
+	}
+	if primitive, ok := m.primitives[top.GetKey()]; ok {
+		m.app.SetFocus(primitive)
+	}
+	return nil
+}
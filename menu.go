@@ -1,7 +1,18 @@
 package main
 
 import (
+	"context"
+	"strings"
+
 	"github.com/rivo/tview"
+
+	"github.com/NeonTowel/gitix/branches"
+	"github.com/NeonTowel/gitix/commits"
+	"github.com/NeonTowel/gitix/diff"
+	"github.com/NeonTowel/gitix/filetree"
+	guicontext "github.com/NeonTowel/gitix/gui/context"
+	"github.com/NeonTowel/gitix/mergeconflicts"
+	"github.com/NeonTowel/gitix/sync"
 )
 
 const (
@@ -71,30 +82,51 @@ A branch is like a separate workspace for your changes.`,
 "Other Options" lets you change extra settings.`,
 }
 
-func createSaveChangesSubmenu(actionPanel *tview.TextView) *tview.List {
+func createSaveChangesSubmenu(actionPanel *tview.TextView, content *tview.Flex, app *tview.Application, manager *guicontext.Manager, mode *diff.Mode) *tview.List {
+	openHistory := func() {
+		ctx := commits.NewContext(content, app, actionPanel, func() {
+			_ = manager.Pop()
+		}, mode)
+		_ = manager.Push(ctx)
+	}
 	return tview.NewList().
 		AddItem("Save Now", "Save your current work", 'n', nil).
 		AddItem("Fix Last Save", "Change your last saved work", 'a', nil).
 		AddItem("Undo Changes", "Discard changes since last save", 'u', nil).
-		AddItem("View History", "See past saved work", 'v', nil).
-		AddItem("Search History", "Find saved work by keyword", 's', nil).
+		AddItem("View History", "See past saved work", 'v', openHistory).
+		AddItem("Search History", "Find saved work by keyword", 's', openHistory).
 		AddItem("Help", "What is saving?", 'h', func() {
 			actionPanel.SetText(helpTexts[SaveChangesKey])
 		})
 }
 
-func createCheckFilesSubmenu(actionPanel *tview.TextView) *tview.List {
+func createCheckFilesSubmenu(actionPanel *tview.TextView, content *tview.Flex, app *tview.Application, manager *guicontext.Manager) *tview.List {
 	return tview.NewList().
-		AddItem("Show Changes", "See what files changed", 's', nil).
+		AddItem("Show Changes", "See what files changed", 's', func() {
+			ctx := filetree.NewContext(content, app, actionPanel, func() {
+				_ = manager.Pop()
+			})
+			_ = manager.Push(ctx)
+		}).
 		AddItem("View File Differences", "See line-by-line changes", 'd', nil).
 		AddItem("Help", "What is checking files?", 'h', func() {
 			actionPanel.SetText(helpTexts[CheckFilesKey])
 		})
 }
 
-func createBranchesSubmenu(actionPanel *tview.TextView) *tview.List {
+func createBranchesSubmenu(actionPanel *tview.TextView, content *tview.Flex, app *tview.Application, manager *guicontext.Manager, mode *diff.Mode) *tview.List {
 	return tview.NewList().
-		AddItem("Show Branches", "See all versions of your work", 'l', nil).
+		AddItem("Show Branches", "See all versions of your work", 'l', func() {
+			ctx := branches.NewContext(content, app, actionPanel, func() {
+				_ = manager.Pop()
+			}, mode, func() {
+				conflicts := mergeconflicts.NewContext(content, app, actionPanel, func() {
+					_ = manager.Pop()
+				})
+				_ = manager.Push(conflicts)
+			})
+			_ = manager.Push(ctx)
+		}).
 		AddItem("New Branch", "Start a new version of your work", 'c', nil).
 		AddItem("Remove Branch", "Delete a version of your work", 'd', nil).
 		AddItem("Merge Branch", "Combine changes from one version into another", 'm', nil).
@@ -104,12 +136,67 @@ func createBranchesSubmenu(actionPanel *tview.TextView) *tview.List {
 		})
 }
 
-func createSyncChangesSubmenu(actionPanel *tview.TextView) *tview.List {
+// runSync runs action in the background through sync.Default, streaming its
+// output into actionPanel line by line as it arrives instead of freezing
+// the UI until it exits. Must be called from the main (event-handling)
+// goroutine; the streaming itself, and the QueueUpdateDraw calls that
+// reflect it, happen off that goroutine. A Ctrl-C while it's running calls
+// sync.Default.Cancel (wired in main.go) to interrupt it. If action fails
+// and leaves conflict markers behind (a pull whose merge collided),
+// onConflict is called instead of showing the raw error, so the caller can
+// push the merge-conflicts panel on top of this one; onConflict may be nil
+// for actions (push, fetch) that can't leave conflicts.
+func runSync(app *tview.Application, actionPanel *tview.TextView, action func(context.Context, func(string)) error, onConflict func()) {
+	go func() {
+		var lines []string
+		render := func() {
+			app.QueueUpdateDraw(func() {
+				actionPanel.SetText(strings.Join(lines, "\n"))
+			})
+		}
+		err := sync.Default.Run(action, func(line string) {
+			lines = append(lines, line)
+			render()
+		})
+		if err != nil {
+			if has, _ := mergeconflicts.HasConflicts(); has && onConflict != nil {
+				app.QueueUpdateDraw(onConflict)
+				return
+			}
+			lines = append(lines, err.Error())
+			render()
+		}
+	}()
+}
+
+func createSyncChangesSubmenu(actionPanel *tview.TextView, app *tview.Application, content *tview.Flex, manager *guicontext.Manager) *tview.List {
+	onConflict := func() {
+		conflicts := mergeconflicts.NewContext(content, app, actionPanel, func() {
+			_ = manager.Pop()
+		})
+		_ = manager.Push(conflicts)
+	}
 	return tview.NewList().
-		AddItem("Send Updates", "Send your work to the central place", 'p', nil).
-		AddItem("Get Updates", "Get work from others", 'l', nil).
-		AddItem("Check for Updates", "See if others have new work", 'f', nil).
-		AddItem("Sync All", "Send and get updates", 's', nil).
+		AddItem("Send Updates", "Send your work to the central place", 'p', func() {
+			runSync(app, actionPanel, sync.Push, nil)
+		}).
+		AddItem("Get Updates", "Get work from others", 'l', func() {
+			runSync(app, actionPanel, sync.Pull, onConflict)
+		}).
+		AddItem("Check for Updates", "See if others have new work", 'f', func() {
+			runSync(app, actionPanel, sync.Fetch, nil)
+		}).
+		AddItem("Sync All", "Send and get updates", 's', func() {
+			runSync(app, actionPanel, func(ctx context.Context, onLine func(string)) error {
+				if err := sync.Fetch(ctx, onLine); err != nil {
+					return err
+				}
+				if err := sync.Pull(ctx, onLine); err != nil {
+					return err
+				}
+				return sync.Push(ctx, onLine)
+			}, onConflict)
+		}).
 		AddItem("Help", "What is syncing?", 'h', func() {
 			actionPanel.SetText(helpTexts[SyncChangesKey])
 		})
@@ -137,12 +224,12 @@ func createMainMenu(app *tview.Application) *tview.List {
 		})
 }
 
-func createMenu(app *tview.Application, actionPanel *tview.TextView) (*tview.List, map[string]*tview.List) {
+func createMenu(app *tview.Application, actionPanel *tview.TextView, content *tview.Flex, manager *guicontext.Manager, mode *diff.Mode) (*tview.List, map[string]*tview.List) {
 	submenus := map[string]*tview.List{
-		SaveChangesKey: createSaveChangesSubmenu(actionPanel),
-		CheckFilesKey:  createCheckFilesSubmenu(actionPanel),
-		BranchesKey:    createBranchesSubmenu(actionPanel),
-		SyncChangesKey: createSyncChangesSubmenu(actionPanel),
+		SaveChangesKey: createSaveChangesSubmenu(actionPanel, content, app, manager, mode),
+		CheckFilesKey:  createCheckFilesSubmenu(actionPanel, content, app, manager),
+		BranchesKey:    createBranchesSubmenu(actionPanel, content, app, manager, mode),
+		SyncChangesKey: createSyncChangesSubmenu(actionPanel, app, content, manager),
 		SettingsKey:    createSettingsSubmenu(actionPanel),
 	}
 
@@ -0,0 +1,87 @@
+package mergeconflicts
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Resolution picks which side(s) of a hunk to keep.
+type Resolution int
+
+const (
+	PickOurs Resolution = iota
+	PickTheirs
+	PickBoth
+)
+
+// Pick resolves the hunkIndex-th conflict hunk still present in file,
+// replacing its marker block with the chosen side(s) and rewriting the
+// file in place. It re-scans the file rather than trusting stale line
+// numbers, since resolving an earlier hunk shifts every hunk after it.
+func Pick(file string, hunkIndex int, resolution Resolution) error {
+	conflict, err := ScanFile(file)
+	if err != nil {
+		return err
+	}
+	if conflict == nil || hunkIndex < 0 || hunkIndex >= len(conflict.Hunks) {
+		return fmt.Errorf("mergeconflicts: no hunk %d in %s", hunkIndex, file)
+	}
+	hunk := conflict.Hunks[hunkIndex]
+
+	var resolved []string
+	switch resolution {
+	case PickOurs:
+		resolved = hunk.Ours
+	case PickTheirs:
+		resolved = hunk.Theirs
+	case PickBoth:
+		resolved = append(append([]string{}, hunk.Ours...), hunk.Theirs...)
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	lines := strings.Split(string(data), "\n")
+
+	var out []string
+	out = append(out, lines[:hunk.StartLine-1]...)
+	out = append(out, resolved...)
+	out = append(out, lines[hunk.EndLine:]...)
+
+	return os.WriteFile(file, []byte(strings.Join(out, "\n")), 0o644)
+}
+
+// StageFile marks file as resolved with `git add`.
+func StageFile(file string) error {
+	return run("add", "--", file)
+}
+
+// HasConflicts reports whether git still lists any unmerged (conflicted)
+// path, as ListConflictedFiles does.
+func HasConflicts() (bool, error) {
+	files, err := ListConflictedFiles()
+	if err != nil {
+		return false, err
+	}
+	return len(files) > 0, nil
+}
+
+// FinishMerge runs `git commit --no-edit`, completing a merge once every
+// conflicted file has been staged.
+func FinishMerge() error {
+	return run("commit", "--no-edit")
+}
+
+func run(args ...string) error {
+	cmd := exec.Command("git", args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("git %s: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
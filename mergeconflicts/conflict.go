@@ -0,0 +1,106 @@
+// Package mergeconflicts scans working-tree files for unresolved merge
+// conflict markers left by a failed merge or pull, and lets the user
+// resolve them hunk by hunk.
+package mergeconflicts
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Hunk is one conflicted region within a file, bounded by a <<<<<<< and a
+// >>>>>>> marker. StartLine/EndLine are 1-indexed line numbers in the
+// file's current content, inclusive of the markers themselves.
+type Hunk struct {
+	Ours      []string
+	Base      []string
+	Theirs    []string
+	StartLine int
+	EndLine   int
+}
+
+// Conflict is one file with one or more unresolved hunks.
+type Conflict struct {
+	File  string
+	Hunks []Hunk
+}
+
+// ListConflictedFiles returns the paths git reports as unmerged.
+func ListConflictedFiles() ([]string, error) {
+	out, err := exec.Command("git", "diff", "--name-only", "--diff-filter=U").Output()
+	if err != nil {
+		return nil, fmt.Errorf("git diff --diff-filter=U: %w", err)
+	}
+	trimmed := strings.TrimRight(string(out), "\n")
+	if trimmed == "" {
+		return nil, nil
+	}
+	return strings.Split(trimmed, "\n"), nil
+}
+
+// ScanFile parses path for conflict markers and returns its Conflict, or
+// nil if the file currently has none.
+func ScanFile(path string) (*Conflict, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var hunks []Hunk
+	var cur *Hunk
+	var side *[]string
+	lineNo := 0
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1<<20)
+	for scanner.Scan() {
+		lineNo++
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "<<<<<<<"):
+			cur = &Hunk{StartLine: lineNo}
+			side = &cur.Ours
+		case cur != nil && strings.HasPrefix(line, "|||||||"):
+			side = &cur.Base
+		case cur != nil && strings.HasPrefix(line, "======="):
+			side = &cur.Theirs
+		case cur != nil && strings.HasPrefix(line, ">>>>>>>"):
+			cur.EndLine = lineNo
+			hunks = append(hunks, *cur)
+			cur = nil
+			side = nil
+		case cur != nil:
+			*side = append(*side, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(hunks) == 0 {
+		return nil, nil
+	}
+	return &Conflict{File: path, Hunks: hunks}, nil
+}
+
+// ScanConflicts scans every file git reports as unmerged.
+func ScanConflicts() ([]Conflict, error) {
+	files, err := ListConflictedFiles()
+	if err != nil {
+		return nil, err
+	}
+	var conflicts []Conflict
+	for _, f := range files {
+		c, err := ScanFile(f)
+		if err != nil {
+			return nil, err
+		}
+		if c != nil {
+			conflicts = append(conflicts, *c)
+		}
+	}
+	return conflicts, nil
+}
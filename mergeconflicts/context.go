@@ -0,0 +1,40 @@
+package mergeconflicts
+
+import (
+	"github.com/rivo/tview"
+)
+
+// contextKey is the Context identifier registered with the ContextManager.
+const contextKey = "Merge Conflicts"
+
+// Context adapts the conflicts panel to the gui/context.Context interface
+// so it can be pushed automatically when a merge or pull exits with
+// conflicts, and popped back to whatever submenu triggered it on Esc.
+type Context struct {
+	container   *tview.Flex
+	app         *tview.Application
+	actionPanel *tview.TextView
+	view        tview.Primitive
+	onCancel    func()
+}
+
+// NewContext builds a mergeconflicts Context rendering into container.
+func NewContext(container *tview.Flex, app *tview.Application, actionPanel *tview.TextView, onCancel func()) *Context {
+	return &Context{container: container, app: app, actionPanel: actionPanel, onCancel: onCancel}
+}
+
+func (c *Context) GetKey() string { return contextKey }
+
+func (c *Context) HandleFocus() error {
+	if c.view != nil {
+		c.app.SetFocus(c.view)
+	}
+	return nil
+}
+
+func (c *Context) HandleFocusLost() error { return nil }
+
+func (c *Context) HandleRender() error {
+	c.view = ShowConflictsUI(c.container, c.app, c.actionPanel, c.onCancel)
+	return nil
+}
@@ -0,0 +1,134 @@
+package mergeconflicts
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// hunkRow is one visible line of the current hunk's three-way display.
+type hunkRow struct {
+	label string
+	text  string
+	color tcell.Color
+}
+
+// HunkView renders the selected conflict's current hunk with ours/base/
+// theirs stacked, and lets the user pick a resolution for it.
+type HunkView struct {
+	*tview.Box
+
+	conflict *Conflict
+	hunk     int
+	rows     []hunkRow
+
+	onPick   func(hunkIndex int, resolution Resolution)
+	onUndo   func()
+	onSave   func()
+	onEditor func(hunkIndex int)
+}
+
+// NewHunkView creates an empty hunk view. The callbacks fire on 1 (ours),
+// 2 (theirs), b (both), u (undo last pick), s (save + stage), and e (open
+// $EDITOR at the hunk).
+func NewHunkView(onPick func(hunkIndex int, resolution Resolution), onUndo, onSave func(), onEditor func(hunkIndex int)) *HunkView {
+	return &HunkView{
+		Box:      tview.NewBox().SetBorder(true).SetTitle("Conflict"),
+		onPick:   onPick,
+		onUndo:   onUndo,
+		onSave:   onSave,
+		onEditor: onEditor,
+	}
+}
+
+// SetConflict loads a new file's conflict into the view, resetting the
+// cursor to its first hunk.
+func (v *HunkView) SetConflict(c *Conflict) {
+	v.conflict = c
+	v.hunk = 0
+	v.layout()
+}
+
+func (v *HunkView) layout() {
+	v.rows = nil
+	if v.conflict == nil || v.hunk >= len(v.conflict.Hunks) {
+		return
+	}
+	h := v.conflict.Hunks[v.hunk]
+	v.rows = append(v.rows, hunkRow{label: "<<<<<<< ours", color: tcell.ColorGreen})
+	for _, l := range h.Ours {
+		v.rows = append(v.rows, hunkRow{text: l, color: tcell.ColorGreen})
+	}
+	if len(h.Base) > 0 {
+		v.rows = append(v.rows, hunkRow{label: "||||||| base", color: tcell.ColorYellow})
+		for _, l := range h.Base {
+			v.rows = append(v.rows, hunkRow{text: l, color: tcell.ColorYellow})
+		}
+	}
+	v.rows = append(v.rows, hunkRow{label: "=======", color: tcell.ColorWhite})
+	for _, l := range h.Theirs {
+		v.rows = append(v.rows, hunkRow{text: l, color: tcell.ColorRed})
+	}
+	v.rows = append(v.rows, hunkRow{label: ">>>>>>> theirs", color: tcell.ColorRed})
+}
+
+func (v *HunkView) Draw(screen tcell.Screen) {
+	v.Box.DrawForSubclass(screen, v)
+	x, y, width, height := v.GetInnerRect()
+
+	if v.conflict != nil {
+		v.SetTitle("Conflict " + v.conflict.File)
+	}
+
+	for i, row := range v.rows {
+		if i >= height {
+			break
+		}
+		text := row.label + row.text
+		style := tcell.StyleDefault.Foreground(row.color)
+		col := x
+		for _, r := range text {
+			if col >= x+width {
+				break
+			}
+			screen.SetContent(col, y+i, r, nil, style)
+			col++
+		}
+	}
+}
+
+func (v *HunkView) InputHandler() func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+	return v.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+		switch event.Key() {
+		case tcell.KeyRune:
+			switch event.Rune() {
+			case '1':
+				v.pick(PickOurs)
+			case '2':
+				v.pick(PickTheirs)
+			case 'b':
+				v.pick(PickBoth)
+			case 'u':
+				if v.onUndo != nil {
+					v.onUndo()
+				}
+			case 's':
+				if v.onSave != nil {
+					v.onSave()
+				}
+			case 'e':
+				if v.onEditor != nil {
+					v.onEditor(v.hunk)
+				}
+			}
+		}
+	})
+}
+
+func (v *HunkView) pick(resolution Resolution) {
+	if v.conflict == nil || v.hunk >= len(v.conflict.Hunks) {
+		return
+	}
+	if v.onPick != nil {
+		v.onPick(v.hunk, resolution)
+	}
+}
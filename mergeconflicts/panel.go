@@ -0,0 +1,160 @@
+package mergeconflicts
+
+import (
+	"os"
+	"os/exec"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// ShowConflictsUI renders the conflicted-files list and the selected
+// file's current hunk into container. It mirrors save.ShowSaveUI's shape:
+// build into the container, report progress through actionPanel, and call
+// onCancel when the user backs out with Esc. Once git lists no conflicted
+// files, it finishes the merge straight away with `git commit --no-edit`.
+func ShowConflictsUI(container *tview.Flex, app *tview.Application, actionPanel *tview.TextView, onCancel func()) tview.Primitive {
+	fileList := tview.NewList().ShowSecondaryText(false)
+	fileList.SetBorder(true).SetTitle("Conflicted Files")
+
+	backups := map[string]string{} // last saved content, for undo
+
+	var view *HunkView
+	var render func()
+
+	loadFile := func(file string) {
+		conflict, err := ScanFile(file)
+		if err != nil {
+			actionPanel.SetText("Error scanning " + file + ": " + err.Error())
+			return
+		}
+		if conflict == nil {
+			conflict = &Conflict{File: file}
+		}
+		view.SetConflict(conflict)
+		app.SetFocus(view)
+	}
+
+	render = func() {
+		files, err := ListConflictedFiles()
+		if err != nil {
+			actionPanel.SetText("Error listing conflicts: " + err.Error())
+			return
+		}
+		fileList.Clear()
+		for _, f := range files {
+			file := f
+			fileList.AddItem(file, "", 0, func() { loadFile(file) })
+		}
+		if len(files) == 0 {
+			actionPanel.SetText("No conflicts remain. Finishing merge...")
+			if err := FinishMerge(); err != nil {
+				actionPanel.SetText("Error finishing merge: " + err.Error())
+				return
+			}
+			actionPanel.SetText("Merge complete.")
+			return
+		}
+		loadFile(files[0])
+	}
+
+	backup := func(file string) {
+		data, err := os.ReadFile(file)
+		if err == nil {
+			backups[file] = string(data)
+		}
+	}
+
+	view = NewHunkView(
+		func(hunkIndex int, resolution Resolution) {
+			file := view.conflict.File
+			backup(file)
+			if err := Pick(file, hunkIndex, resolution); err != nil {
+				actionPanel.SetText(err.Error())
+				return
+			}
+			loadFile(file)
+		},
+		func() {
+			if view.conflict == nil {
+				return
+			}
+			file := view.conflict.File
+			data, ok := backups[file]
+			if !ok {
+				actionPanel.SetText("Nothing to undo for " + file)
+				return
+			}
+			if err := os.WriteFile(file, []byte(data), 0o644); err != nil {
+				actionPanel.SetText(err.Error())
+				return
+			}
+			delete(backups, file)
+			loadFile(file)
+		},
+		func() {
+			if view.conflict == nil {
+				return
+			}
+			file := view.conflict.File
+			conflict, err := ScanFile(file)
+			if err != nil {
+				actionPanel.SetText(err.Error())
+				return
+			}
+			if conflict != nil {
+				actionPanel.SetText(file + " still has unresolved hunks.")
+				return
+			}
+			if err := StageFile(file); err != nil {
+				actionPanel.SetText(err.Error())
+				return
+			}
+			render()
+		},
+		func(hunkIndex int) {
+			if view.conflict == nil {
+				return
+			}
+			file := view.conflict.File
+			editor := os.Getenv("EDITOR")
+			if editor == "" {
+				editor = "vi"
+			}
+			app.Suspend(func() {
+				cmd := exec.Command(editor, file)
+				cmd.Stdin = os.Stdin
+				cmd.Stdout = os.Stdout
+				cmd.Stderr = os.Stderr
+				_ = cmd.Run()
+			})
+			loadFile(file)
+		},
+	)
+
+	render()
+
+	cancelFunc := func() {
+		container.Clear()
+		actionPanel.SetText("")
+		if onCancel != nil {
+			onCancel()
+		}
+	}
+
+	panels := tview.NewFlex().
+		AddItem(fileList, 0, 1, true).
+		AddItem(view, 0, 2, false)
+
+	panels.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc {
+			cancelFunc()
+			return nil
+		}
+		return event
+	})
+
+	container.Clear()
+	container.AddItem(panels, 0, 1, true)
+	return panels
+}
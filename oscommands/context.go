@@ -0,0 +1,52 @@
+package oscommands
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// contextKey is the Context identifier registered with the ContextManager.
+const contextKey = "Command Log"
+
+// Context adapts the Command Log panel to the gui/context.Context
+// interface so it can be pushed onto the ContextManager's stack with the
+// backtick key from anywhere but the Changed Files panel (which binds
+// backtick itself), and popped back to whatever was active
+// before it on Esc or a second backtick.
+type Context struct {
+	container *tview.Flex
+	app       *tview.Application
+	runner    *Runner
+	panel     *LogPanel
+	onCancel  func()
+}
+
+// NewContext builds a Command Log Context reading from runner.
+func NewContext(container *tview.Flex, app *tview.Application, runner *Runner, onCancel func()) *Context {
+	return &Context{container: container, app: app, runner: runner, onCancel: onCancel, panel: NewLogPanel()}
+}
+
+func (c *Context) GetKey() string { return contextKey }
+
+func (c *Context) HandleFocus() error {
+	c.app.SetFocus(c.panel)
+	return nil
+}
+
+func (c *Context) HandleFocusLost() error { return nil }
+
+func (c *Context) HandleRender() error {
+	c.panel.Refresh(c.runner)
+	c.panel.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyEsc {
+			if c.onCancel != nil {
+				c.onCancel()
+			}
+			return nil
+		}
+		return event
+	})
+	c.container.Clear()
+	c.container.AddItem(c.panel, 0, 1, true)
+	return nil
+}
@@ -0,0 +1,37 @@
+package oscommands
+
+import (
+	"fmt"
+
+	"github.com/rivo/tview"
+)
+
+// LogPanel renders a Runner's command log as a scrolling TextView, with
+// failing commands (non-zero exit code) highlighted in red.
+type LogPanel struct {
+	*tview.TextView
+}
+
+// NewLogPanel creates an empty Command Log panel.
+func NewLogPanel() *LogPanel {
+	view := tview.NewTextView().SetDynamicColors(true)
+	view.SetBorder(true).SetTitle("Command Log")
+	return &LogPanel{TextView: view}
+}
+
+// Refresh redraws the panel from runner's current log, most recent last.
+func (p *LogPanel) Refresh(runner *Runner) {
+	p.Clear()
+	for _, entry := range runner.Log() {
+		color := "white"
+		if entry.ExitCode != 0 {
+			color = "red"
+		}
+		fmt.Fprintf(p.TextView, "[%s]%s  %s %v  (exit %d, %s)[-]\n",
+			color,
+			entry.Time.Format("15:04:05"),
+			entry.Cmd, entry.Args,
+			entry.ExitCode, entry.Duration.Round(1e6))
+	}
+	p.ScrollToEnd()
+}
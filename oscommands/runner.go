@@ -0,0 +1,138 @@
+// Package oscommands runs external commands (almost always git) with
+// visibility: every invocation is recorded in a bounded log for the
+// Command Log panel, and long-running commands can stream their output
+// instead of going silent until they exit.
+package oscommands
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LogEntry records one command a Runner executed.
+type LogEntry struct {
+	Time     time.Time
+	Cmd      string
+	Args     []string
+	ExitCode int
+	Duration time.Duration
+}
+
+// logCapacity bounds the in-memory command log so a long session doesn't
+// grow it without limit.
+const logCapacity = 200
+
+// Runner executes external commands, recording each one in a ring buffer.
+type Runner struct {
+	mu  sync.Mutex
+	log []LogEntry
+}
+
+// NewRunner creates an empty Runner.
+func NewRunner() *Runner {
+	return &Runner{}
+}
+
+// Log returns a copy of the command log, oldest first.
+func (r *Runner) Log() []LogEntry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]LogEntry, len(r.log))
+	copy(out, r.log)
+	return out
+}
+
+func (r *Runner) record(entry LogEntry) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.log = append(r.log, entry)
+	if len(r.log) > logCapacity {
+		r.log = r.log[len(r.log)-logCapacity:]
+	}
+}
+
+// RunWithOutput runs name with args to completion and returns its combined
+// stdout/stderr.
+func (r *Runner) RunWithOutput(ctx context.Context, name string, args ...string) (string, error) {
+	start := time.Now()
+	cmd := exec.CommandContext(ctx, name, args...)
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	err := cmd.Run()
+
+	r.record(LogEntry{Time: start, Cmd: name, Args: args, ExitCode: exitCode(err), Duration: time.Since(start)})
+	if err != nil {
+		return out.String(), fmt.Errorf("%s %s: %w: %s", name, strings.Join(args, " "), err, strings.TrimSpace(out.String()))
+	}
+	return out.String(), nil
+}
+
+// Stream runs name with args and sends its combined stdout/stderr a line at
+// a time on the returned channel, so long-running commands like push,
+// pull, fetch, and rebase -i can show progress instead of appearing to
+// hang. Cancelling ctx (e.g. on Ctrl-C) kills the command. The error
+// channel receives exactly one value, once the command exits, after lines
+// is closed.
+func (r *Runner) Stream(ctx context.Context, name string, args ...string) (<-chan string, <-chan error) {
+	lines := make(chan string)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(errs)
+
+		start := time.Now()
+		cmd := exec.CommandContext(ctx, name, args...)
+		pr, pw := io.Pipe()
+		cmd.Stdout = pw
+		cmd.Stderr = pw
+
+		if err := cmd.Start(); err != nil {
+			close(lines)
+			errs <- err
+			return
+		}
+
+		waitErr := make(chan error, 1)
+		go func() {
+			waitErr <- cmd.Wait()
+			pw.Close()
+		}()
+
+		scanner := bufio.NewScanner(pr)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+
+		err := <-waitErr
+		close(lines)
+		r.record(LogEntry{Time: start, Cmd: name, Args: args, ExitCode: exitCode(err), Duration: time.Since(start)})
+		errs <- err
+	}()
+
+	return lines, errs
+}
+
+// Default is the Runner used by packages (like save) that don't need their
+// own isolated command log, so every invocation shows up in one Command
+// Log panel.
+var Default = NewRunner()
+
+func exitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode()
+	}
+	return -1
+}
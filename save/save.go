@@ -1,31 +1,54 @@
 package save
 
 import (
-	"bytes"
-	"os/exec"
+	"context"
 	"strings"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
+
+	"github.com/NeonTowel/gitix/oscommands"
 )
 
-// GetChangedFiles returns a list of changed files (staged and unstaged) in the git repo
-func GetChangedFiles() ([]string, error) {
-	cmd := exec.Command("git", "status", "--porcelain")
-	var out bytes.Buffer
-	cmd.Stdout = &out
-	if err := cmd.Run(); err != nil {
+// File describes one entry from `git status --porcelain`: its path, the
+// staged (index) and unstaged (worktree) status bytes, and, for renames and
+// copies, the path it was renamed/copied from.
+type File struct {
+	Path           string
+	OrigPath       string
+	StagedStatus   byte
+	UnstagedStatus byte
+}
+
+// GetChangedFiles returns every changed file (staged and unstaged) in the
+// git repo, parsed from the porcelain status format: the XY byte pair gives
+// the staged and unstaged status, the path follows from offset 3, and
+// renames ("R  old -> new") report both paths instead of the raw
+// "old -> new" string.
+func GetChangedFiles() ([]File, error) {
+	out, err := oscommands.Default.RunWithOutput(context.Background(), "git", "status", "--porcelain")
+	if err != nil {
 		return nil, err
 	}
-	lines := strings.Split(out.String(), "\n")
-	files := []string{}
+	lines := strings.Split(out, "\n")
+	files := []File{}
 	for _, line := range lines {
 		if len(line) < 4 {
 			continue
 		}
-		// line format: XY filename
-		filename := strings.TrimSpace(line[3:])
-		files = append(files, filename)
+		rest := strings.TrimSpace(line[3:])
+		file := File{
+			StagedStatus:   line[0],
+			UnstagedStatus: line[1],
+			Path:           rest,
+		}
+		if line[0] == 'R' || line[0] == 'C' {
+			if orig, renamed, ok := strings.Cut(rest, " -> "); ok {
+				file.OrigPath = orig
+				file.Path = renamed
+			}
+		}
+		files = append(files, file)
 	}
 	return files, nil
 }
@@ -33,14 +56,14 @@ func GetChangedFiles() ([]string, error) {
 // StageFiles stages the given files for commit
 func StageFiles(files []string) error {
 	args := append([]string{"add"}, files...)
-	cmd := exec.Command("git", args...)
-	return cmd.Run()
+	_, err := oscommands.Default.RunWithOutput(context.Background(), "git", args...)
+	return err
 }
 
 // Commit commits staged changes with the given commit message
 func Commit(message string) error {
-	cmd := exec.Command("git", "commit", "-m", message)
-	return cmd.Run()
+	_, err := oscommands.Default.RunWithOutput(context.Background(), "git", "commit", "-m", message)
+	return err
 }
 
 // SaveNow is deprecated, use StageFiles and Commit instead
@@ -48,7 +71,12 @@ func SaveNow() error {
 	return nil
 }
 
-// ShowSaveUI updates the given container (action panel) with the save UI
+// ShowSaveUI updates the given container (action panel) with the save UI.
+// Files are staged at the line/hunk level through a StagingPanel rather
+// than the file list's former whole-file checkbox: selecting a file opens
+// its diff in the panel, where Space selects the line under the cursor,
+// Enter/u stage or unstage the selected lines via ApplyPatch, and a stages
+// the whole hunk under the cursor.
 func ShowSaveUI(container *tview.Flex, app *tview.Application, actionPanel *tview.TextView, onCancel func()) tview.Primitive {
 	files, err := GetChangedFiles()
 	if err != nil {
@@ -59,20 +87,50 @@ func ShowSaveUI(container *tview.Flex, app *tview.Application, actionPanel *tvie
 	// Clear container
 	container.Clear()
 
-	// Create checkbox list for files
 	fileList := tview.NewList().ShowSecondaryText(false)
-	selectedFiles := map[int]string{}
-	for i, f := range files {
-		fileName := f
-		fileList.AddItem(f, "", 0, func() {
-			// Toggle selection
-			if _, ok := selectedFiles[i]; ok {
-				delete(selectedFiles, i)
-				fileList.SetItemText(i, fileName, "")
-			} else {
-				selectedFiles[i] = fileName
-				fileList.SetItemText(i, "[x] "+fileName, "")
+	fileList.SetBorder(true).SetTitle("Changed Files")
+
+	var panel *StagingPanel
+
+	// reload re-reads file's diff from git and pushes it back into the
+	// panel, so the hunk offsets BuildPatch works from next are never
+	// stale after a stage/unstage has just shifted them.
+	reload := func(file string) {
+		patch, err := LoadHunkPatch(file)
+		if err != nil {
+			actionPanel.SetText("Error loading diff: " + err.Error())
+			return
+		}
+		panel.SetPatch(patch)
+	}
+
+	panel = NewStagingPanel(
+		func(file string, hunk Hunk, lineSelected map[int]bool) error {
+			if err := ApplyPatch(BuildPatch(file, hunk, lineSelected, false), false); err != nil {
+				return err
+			}
+			reload(file)
+			return nil
+		},
+		func(file string, hunk Hunk, lineSelected map[int]bool) error {
+			if err := ApplyPatch(BuildPatch(file, hunk, lineSelected, false), true); err != nil {
+				return err
 			}
+			reload(file)
+			return nil
+		},
+		func(s string) { actionPanel.SetText(s) },
+	)
+
+	openFile := func(fileName string) {
+		reload(fileName)
+		app.SetFocus(panel)
+	}
+
+	for _, f := range files {
+		fileName := f.Path
+		fileList.AddItem(fileName, string(f.StagedStatus)+string(f.UnstagedStatus), 0, func() {
+			openFile(fileName)
 		})
 	}
 
@@ -83,30 +141,13 @@ func ShowSaveUI(container *tview.Flex, app *tview.Application, actionPanel *tvie
 
 	// Submit button
 	submitButton := tview.NewButton("Commit").SetSelectedFunc(func() {
-		if len(selectedFiles) == 0 {
-			actionPanel.SetText("No files selected to commit.")
-			return
-		}
 		message := commitInput.GetText()
 		if strings.TrimSpace(message) == "" {
 			actionPanel.SetText("Commit message cannot be empty.")
 			return
 		}
 
-		// Stage files
-		filesToStage := []string{}
-		for _, f := range selectedFiles {
-			filesToStage = append(filesToStage, f)
-		}
-		err := StageFiles(filesToStage)
-		if err != nil {
-			actionPanel.SetText("Error staging files: " + err.Error())
-			return
-		}
-
-		// Commit
-		err = Commit(message)
-		if err != nil {
+		if err := Commit(message); err != nil {
 			actionPanel.SetText("Error committing: " + err.Error())
 			return
 		}
@@ -126,9 +167,13 @@ func ShowSaveUI(container *tview.Flex, app *tview.Application, actionPanel *tvie
 
 	cancelButton := tview.NewButton("Cancel (Esc)").SetSelectedFunc(cancelFunc)
 
+	panels := tview.NewFlex().
+		AddItem(fileList, 0, 1, true).
+		AddItem(panel, 0, 2, false)
+
 	// Layout inside container
 	formFlex := tview.NewFlex().SetDirection(tview.FlexRow).
-		AddItem(fileList, 0, 1, true).
+		AddItem(panels, 0, 1, true).
 		AddItem(commitInput, 1, 0, false).
 		AddItem(submitButton, 1, 0, false).
 		AddItem(cancelButton, 1, 0, false)
@@ -0,0 +1,191 @@
+package save
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// DiffLine is one line of a hunk's body: a context line (' '), an added
+// line ('+'), or a removed line ('-').
+type DiffLine struct {
+	Kind rune
+	Text string
+}
+
+// Hunk is one `@@ -a,b +c,d @@` section of a unified diff.
+type Hunk struct {
+	OldStart, OldLines int
+	NewStart, NewLines int
+	Lines              []DiffLine
+}
+
+// HunkPatch is the parsed `git diff --no-color -U0` output for a single
+// file, split into hunks the staging panel can select lines within.
+type HunkPatch struct {
+	File  string
+	Hunks []Hunk
+}
+
+var hunkHeaderRE = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? \+(\d+)(?:,(\d+))? @@`)
+
+// LoadHunkPatch runs `git diff --no-color -U0 -- file` and parses the
+// result into a HunkPatch.
+func LoadHunkPatch(file string) (*HunkPatch, error) {
+	cmd := exec.Command("git", "diff", "--no-color", "-U0", "--", file)
+	var out, stderr bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("git diff: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return parseHunkPatch(file, out.String())
+}
+
+func parseHunkPatch(file, diff string) (*HunkPatch, error) {
+	patch := &HunkPatch{File: file}
+	var current *Hunk
+
+	for _, line := range strings.Split(diff, "\n") {
+		if m := hunkHeaderRE.FindStringSubmatch(line); m != nil {
+			if current != nil {
+				patch.Hunks = append(patch.Hunks, *current)
+			}
+			current = &Hunk{
+				OldStart: atoiOr1(m[1]),
+				OldLines: atoiOrDefault(m[2], 1),
+				NewStart: atoiOr1(m[3]),
+				NewLines: atoiOrDefault(m[4], 1),
+			}
+			continue
+		}
+		if current == nil {
+			// Part of the file-level header (diff --git / --- / +++); not
+			// part of any hunk body, so it's ignored here.
+			continue
+		}
+		switch {
+		case strings.HasPrefix(line, "+"):
+			current.Lines = append(current.Lines, DiffLine{Kind: '+', Text: line[1:]})
+		case strings.HasPrefix(line, "-"):
+			current.Lines = append(current.Lines, DiffLine{Kind: '-', Text: line[1:]})
+		case strings.HasPrefix(line, " "):
+			current.Lines = append(current.Lines, DiffLine{Kind: ' ', Text: line[1:]})
+		}
+	}
+	if current != nil {
+		patch.Hunks = append(patch.Hunks, *current)
+	}
+	return patch, nil
+}
+
+func atoiOr1(s string) int {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 1
+	}
+	return n
+}
+
+func atoiOrDefault(s string, def int) int {
+	if s == "" {
+		return def
+	}
+	return atoiOr1(s)
+}
+
+// BuildPatch synthesizes a minimal unidiff-zero patch for hunk, keeping only
+// the lines selected by lineSelected (indexed into hunk.Lines), and
+// recomputing the "@@ -a,b +c,d @@" header for the resulting subset.
+//
+// Unselected additions are dropped entirely (they haven't happened yet, as
+// far as the index is concerned); unselected removals are kept as context
+// so the line numbering around them still lines up. When reverse is true,
+// the patch instead undoes the selected lines (used for unstaging): '+' and
+// '-' swap roles and the header's old/new sides swap.
+func BuildPatch(file string, hunk Hunk, lineSelected map[int]bool, reverse bool) string {
+	var body strings.Builder
+	oldLines, newLines := 0, 0
+
+	for i, dl := range hunk.Lines {
+		switch dl.Kind {
+		case ' ':
+			body.WriteString(" " + dl.Text + "\n")
+			oldLines++
+			newLines++
+		case '+':
+			if lineSelected[i] {
+				body.WriteString("+" + dl.Text + "\n")
+				newLines++
+			}
+		case '-':
+			if lineSelected[i] {
+				body.WriteString("-" + dl.Text + "\n")
+				oldLines++
+			} else {
+				body.WriteString(" " + dl.Text + "\n")
+				oldLines++
+				newLines++
+			}
+		}
+	}
+
+	header := fmt.Sprintf("@@ -%d,%d +%d,%d @@\n", hunk.OldStart, oldLines, hunk.NewStart, newLines)
+	bodyText := body.String()
+	if reverse {
+		header, bodyText = swapPatchSides(hunk, oldLines, newLines), invertLines(bodyText)
+	}
+
+	var patch strings.Builder
+	fmt.Fprintf(&patch, "diff --git a/%s b/%s\n", file, file)
+	fmt.Fprintf(&patch, "--- a/%s\n", file)
+	fmt.Fprintf(&patch, "+++ b/%s\n", file)
+	patch.WriteString(header)
+	patch.WriteString(bodyText)
+	return patch.String()
+}
+
+func swapPatchSides(hunk Hunk, oldLines, newLines int) string {
+	return fmt.Sprintf("@@ -%d,%d +%d,%d @@\n", hunk.NewStart, newLines, hunk.OldStart, oldLines)
+}
+
+func invertLines(body string) string {
+	var out strings.Builder
+	for _, line := range strings.Split(strings.TrimSuffix(body, "\n"), "\n") {
+		if line == "" {
+			continue
+		}
+		switch line[0] {
+		case '+':
+			out.WriteString("-" + line[1:] + "\n")
+		case '-':
+			out.WriteString("+" + line[1:] + "\n")
+		default:
+			out.WriteString(line + "\n")
+		}
+	}
+	return out.String()
+}
+
+// ApplyPatch pipes patch into `git apply --cached --unidiff-zero -`,
+// staging exactly the lines it describes. When reverse is true, the patch
+// is applied with --reverse, unstaging them instead.
+func ApplyPatch(patch string, reverse bool) error {
+	args := []string{"apply", "--cached", "--unidiff-zero"}
+	if reverse {
+		args = append(args, "--reverse")
+	}
+	args = append(args, "-")
+
+	cmd := exec.Command("git", args...)
+	cmd.Stdin = strings.NewReader(patch)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("git apply: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
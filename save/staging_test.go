@@ -0,0 +1,106 @@
+package save
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// initRepo creates a throwaway git repo with one committed file, then
+// changes the working tree to add three new lines as a single hunk.
+func initRepo(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+
+	run := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+	}
+	run("init", "-q")
+	run("config", "user.email", "test@example.com")
+	run("config", "user.name", "Test")
+
+	path := filepath.Join(dir, "f.txt")
+	if err := os.WriteFile(path, []byte("line1\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	run("add", "f.txt")
+	run("commit", "-q", "-m", "init")
+
+	if err := os.WriteFile(path, []byte("line1\nNEW_A\nNEW_B\nNEW_C\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func inDir(t *testing.T, dir string, fn func()) {
+	t.Helper()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+	fn()
+}
+
+// TestStageTwoLinesInTwoSteps reproduces the corruption the maintainer
+// reported: staging one added line, then staging a second added line from
+// the same hunk without reloading the patch in between, must not reuse the
+// first patch's now-stale hunk header. Each stage here re-runs
+// LoadHunkPatch against the current diff, the same reload ShowSaveUI's
+// stage and unstage callbacks do after every ApplyPatch.
+func TestStageTwoLinesInTwoSteps(t *testing.T) {
+	dir := initRepo(t)
+
+	inDir(t, dir, func() {
+		patch, err := LoadHunkPatch("f.txt")
+		if err != nil {
+			t.Fatalf("LoadHunkPatch: %v", err)
+		}
+		if len(patch.Hunks) != 1 || len(patch.Hunks[0].Lines) != 3 {
+			t.Fatalf("unexpected patch: %+v", patch)
+		}
+
+		// Stage NEW_A (line index 0), then reload before staging NEW_B.
+		stagedPatch := BuildPatch("f.txt", patch.Hunks[0], map[int]bool{0: true}, false)
+		if err := ApplyPatch(stagedPatch, false); err != nil {
+			t.Fatalf("ApplyPatch NEW_A: %v", err)
+		}
+
+		patch, err = LoadHunkPatch("f.txt")
+		if err != nil {
+			t.Fatalf("LoadHunkPatch after first stage: %v", err)
+		}
+		if len(patch.Hunks) != 1 || len(patch.Hunks[0].Lines) != 2 {
+			t.Fatalf("unexpected patch after first stage: %+v", patch)
+		}
+
+		// The remaining unstaged lines are now NEW_B and NEW_C; stage NEW_B.
+		stagedPatch = BuildPatch("f.txt", patch.Hunks[0], map[int]bool{0: true}, false)
+		if err := ApplyPatch(stagedPatch, false); err != nil {
+			t.Fatalf("ApplyPatch NEW_B: %v", err)
+		}
+
+		out, err := exec.Command("git", "show", ":f.txt").Output()
+		if err != nil {
+			t.Fatalf("git show :f.txt: %v", err)
+		}
+		got := string(out)
+		want := "line1\nNEW_A\nNEW_B\n"
+		if got != want {
+			t.Fatalf("staged index content = %q, want %q (NEW_A/NEW_B reversed or duplicated)", got, want)
+		}
+		if strings.Contains(got, "NEW_C") {
+			t.Fatalf("staged index content = %q, should not include NEW_C yet", got)
+		}
+	})
+}
@@ -0,0 +1,265 @@
+package save
+
+import (
+	"sort"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+// flatLine is one row of the staging panel's display: the hunk and line it
+// came from, flattened across all hunks so the cursor can move through the
+// whole diff with Up/Down.
+type flatLine struct {
+	hunkIndex int
+	lineIndex int
+	line      DiffLine
+}
+
+// StagingPanel shows the diff of the currently selected file with a cursor
+// over individual lines, supporting line-, range-, and hunk-level staging.
+type StagingPanel struct {
+	*tview.Box
+
+	patch    *HunkPatch
+	flat     []flatLine
+	cursor   int
+	selected map[int]bool // indexes into flat
+	rangeAt  int
+	inRange  bool
+
+	onStage   func(file string, hunk Hunk, lineSelected map[int]bool) error
+	onUnstage func(file string, hunk Hunk, lineSelected map[int]bool) error
+	onMessage func(string)
+}
+
+// NewStagingPanel creates an empty staging panel. Callers set onStage and
+// onUnstage to receive the selected lines, scoped to the hunk they belong
+// to, whenever the user presses Enter or u.
+func NewStagingPanel(onStage, onUnstage func(file string, hunk Hunk, lineSelected map[int]bool) error, onMessage func(string)) *StagingPanel {
+	return &StagingPanel{
+		Box:       tview.NewBox().SetBorder(true).SetTitle("Staging"),
+		selected:  map[int]bool{},
+		onStage:   onStage,
+		onUnstage: onUnstage,
+		onMessage: onMessage,
+	}
+}
+
+// SetPatch loads a new file's hunks into the panel and resets the cursor
+// and selection.
+func (p *StagingPanel) SetPatch(patch *HunkPatch) {
+	p.patch = patch
+	p.flat = nil
+	if patch != nil {
+		for hi, h := range patch.Hunks {
+			for li, dl := range h.Lines {
+				p.flat = append(p.flat, flatLine{hunkIndex: hi, lineIndex: li, line: dl})
+			}
+		}
+	}
+	p.cursor = 0
+	p.selected = map[int]bool{}
+	p.inRange = false
+}
+
+func (p *StagingPanel) Draw(screen tcell.Screen) {
+	p.Box.DrawForSubclass(screen, p)
+	x, y, width, height := p.GetInnerRect()
+
+	for i, fl := range p.flat {
+		if i >= height {
+			break
+		}
+		style := tcell.StyleDefault.Foreground(tcell.ColorWhite)
+		switch fl.line.Kind {
+		case '+':
+			style = style.Foreground(tcell.ColorGreen)
+		case '-':
+			style = style.Foreground(tcell.ColorRed)
+		}
+		if p.selected[i] {
+			style = style.Background(tcell.ColorDarkSlateGray)
+		}
+		if i == p.cursor {
+			style = style.Reverse(true)
+		}
+
+		text := string(fl.line.Kind) + " " + fl.line.Text
+		col := x
+		for _, r := range text {
+			if col >= x+width {
+				break
+			}
+			screen.SetContent(col, y+i-p.scrollOffset(height), r, nil, style)
+			col++
+		}
+	}
+}
+
+// scrollOffset keeps the cursor on-screen once the diff is taller than the
+// panel.
+func (p *StagingPanel) scrollOffset(height int) int {
+	if p.cursor < height {
+		return 0
+	}
+	return p.cursor - height + 1
+}
+
+func (p *StagingPanel) InputHandler() func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+	return p.WrapInputHandler(func(event *tcell.EventKey, setFocus func(p tview.Primitive)) {
+		switch event.Key() {
+		case tcell.KeyUp:
+			p.moveCursor(-1)
+		case tcell.KeyDown:
+			p.moveCursor(1)
+		case tcell.KeyRune:
+			switch event.Rune() {
+			case ' ':
+				p.toggleSelection()
+			case 'v':
+				p.toggleRange()
+			case 'a':
+				p.stageHunkUnderCursor()
+			case ']':
+				p.jumpHunk(1)
+			case '[':
+				p.jumpHunk(-1)
+			case 'u':
+				p.commitSelection(true)
+			}
+		case tcell.KeyEnter:
+			p.commitSelection(false)
+		}
+	})
+}
+
+func (p *StagingPanel) moveCursor(delta int) {
+	if len(p.flat) == 0 {
+		return
+	}
+	p.cursor += delta
+	if p.cursor < 0 {
+		p.cursor = 0
+	}
+	if p.cursor >= len(p.flat) {
+		p.cursor = len(p.flat) - 1
+	}
+	if p.inRange {
+		p.selectRange(p.rangeAt, p.cursor)
+	}
+}
+
+func (p *StagingPanel) toggleSelection() {
+	if !p.selectable(p.cursor) {
+		return
+	}
+	if p.selected[p.cursor] {
+		delete(p.selected, p.cursor)
+	} else {
+		p.selected[p.cursor] = true
+	}
+}
+
+func (p *StagingPanel) toggleRange() {
+	if p.inRange {
+		p.inRange = false
+		return
+	}
+	p.inRange = true
+	p.rangeAt = p.cursor
+}
+
+func (p *StagingPanel) selectRange(from, to int) {
+	if to < from {
+		from, to = to, from
+	}
+	for i := from; i <= to; i++ {
+		if p.selectable(i) {
+			p.selected[i] = true
+		}
+	}
+}
+
+func (p *StagingPanel) selectable(i int) bool {
+	if i < 0 || i >= len(p.flat) {
+		return false
+	}
+	return p.flat[i].line.Kind != ' '
+}
+
+func (p *StagingPanel) jumpHunk(direction int) {
+	if p.patch == nil || len(p.flat) == 0 {
+		return
+	}
+	current := p.flat[p.cursor].hunkIndex
+	target := current + direction
+	if target < 0 || target >= len(p.patch.Hunks) {
+		return
+	}
+	for i, fl := range p.flat {
+		if fl.hunkIndex == target {
+			p.cursor = i
+			return
+		}
+	}
+}
+
+// stageHunkUnderCursor selects every changed line in the cursor's hunk and
+// immediately stages it, matching lazygit's "a" shortcut for whole hunks.
+func (p *StagingPanel) stageHunkUnderCursor() {
+	if len(p.flat) == 0 {
+		return
+	}
+	hunkIndex := p.flat[p.cursor].hunkIndex
+	for i, fl := range p.flat {
+		if fl.hunkIndex == hunkIndex && fl.line.Kind != ' ' {
+			p.selected[i] = true
+		}
+	}
+	p.commitSelection(false)
+}
+
+// commitSelection stages (or, when unstage is true, unstages) every selected
+// line, grouped by the hunk it belongs to.
+func (p *StagingPanel) commitSelection(unstage bool) {
+	if p.patch == nil {
+		return
+	}
+	byHunk := map[int]map[int]bool{}
+	for i := range p.selected {
+		fl := p.flat[i]
+		if byHunk[fl.hunkIndex] == nil {
+			byHunk[fl.hunkIndex] = map[int]bool{}
+		}
+		byHunk[fl.hunkIndex][fl.lineIndex] = true
+	}
+
+	// The callbacks may reload the panel, so keep the patch the selection
+	// was made against, and go last hunk first so staging one hunk never
+	// shifts the line numbers of a hunk still waiting.
+	patch := p.patch
+	hunkIndexes := make([]int, 0, len(byHunk))
+	for hunkIndex := range byHunk {
+		hunkIndexes = append(hunkIndexes, hunkIndex)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(hunkIndexes)))
+
+	for _, hunkIndex := range hunkIndexes {
+		hunk, lines := patch.Hunks[hunkIndex], byHunk[hunkIndex]
+		var err error
+		if unstage {
+			err = p.onUnstage(patch.File, hunk, lines)
+		} else {
+			err = p.onStage(patch.File, hunk, lines)
+		}
+		if err != nil {
+			if p.onMessage != nil {
+				p.onMessage(err.Error())
+			}
+			return
+		}
+	}
+	p.selected = map[int]bool{}
+	p.inRange = false
+}
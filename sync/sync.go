@@ -0,0 +1,74 @@
+// Package sync drives gitix's Sync Changes submenu: pushing, pulling, and
+// fetching the current branch's upstream, streaming progress a line at a
+// time instead of leaving the action panel frozen until git exits.
+package sync
+
+import (
+	"context"
+	stdsync "sync"
+
+	"github.com/NeonTowel/gitix/oscommands"
+)
+
+// Push sends the current branch's commits to its upstream.
+func Push(ctx context.Context, onLine func(string)) error {
+	return stream(ctx, onLine, "push")
+}
+
+// Pull fetches and merges the current branch's upstream into it.
+func Pull(ctx context.Context, onLine func(string)) error {
+	return stream(ctx, onLine, "pull")
+}
+
+// Fetch updates remote-tracking branches without touching the working tree.
+func Fetch(ctx context.Context, onLine func(string)) error {
+	return stream(ctx, onLine, "fetch")
+}
+
+func stream(ctx context.Context, onLine func(string), args ...string) error {
+	lines, errs := oscommands.Default.Stream(ctx, "git", args...)
+	for line := range lines {
+		if onLine != nil {
+			onLine(line)
+		}
+	}
+	return <-errs
+}
+
+// Controller tracks the cancel func of whatever Push/Pull/Fetch is
+// currently running, so a Ctrl-C while one is in flight can interrupt it
+// instead of leaving the action panel wedged on a hung credential prompt
+// or a slow connection.
+type Controller struct {
+	mu     stdsync.Mutex
+	cancel context.CancelFunc
+}
+
+// Default is the Controller behind gitix's Sync Changes submenu.
+var Default = &Controller{}
+
+// Run runs action under a context that Cancel can interrupt for as long as
+// Run is executing.
+func (c *Controller) Run(action func(context.Context, func(string)) error, onLine func(string)) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	c.mu.Lock()
+	c.cancel = cancel
+	c.mu.Unlock()
+	defer func() {
+		c.mu.Lock()
+		c.cancel = nil
+		c.mu.Unlock()
+		cancel()
+	}()
+	return action(ctx, onLine)
+}
+
+// Cancel interrupts the action currently running under Run, if any; it's a
+// no-op otherwise.
+func (c *Controller) Cancel() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.cancel != nil {
+		c.cancel()
+	}
+}
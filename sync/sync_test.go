@@ -0,0 +1,98 @@
+package sync
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// TestControllerCancel reproduces a Ctrl-C arriving mid-sync: Cancel must
+// interrupt whatever action Run is currently executing.
+func TestControllerCancel(t *testing.T) {
+	c := &Controller{}
+	started := make(chan struct{})
+	done := make(chan error, 1)
+
+	go func() {
+		done <- c.Run(func(ctx context.Context, onLine func(string)) error {
+			close(started)
+			<-ctx.Done()
+			return ctx.Err()
+		}, nil)
+	}()
+
+	<-started
+	c.Cancel()
+
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Fatalf("Run returned %v, want context.Canceled", err)
+	}
+}
+
+// TestControllerCancelNoop confirms Cancel is a no-op when nothing is
+// running, rather than panicking on a nil cancel func.
+func TestControllerCancelNoop(t *testing.T) {
+	(&Controller{}).Cancel()
+}
+
+// TestPushStreamsToUpstream runs a real push against a local bare remote
+// through Push (and so through Runner.Stream), confirming the new commit
+// reaches the remote.
+func TestPushStreamsToUpstream(t *testing.T) {
+	remote := t.TempDir()
+	if out, err := exec.Command("git", "init", "-q", "--bare", remote).CombinedOutput(); err != nil {
+		t.Fatalf("git init --bare: %v: %s", err, out)
+	}
+
+	repo := t.TempDir()
+	run := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+	}
+	run("init", "-q", "-b", "main")
+	run("config", "user.email", "test@example.com")
+	run("config", "user.name", "Test")
+	if err := os.WriteFile(filepath.Join(repo, "f.txt"), []byte("hi\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	run("add", "f.txt")
+	run("commit", "-q", "-m", "init")
+	run("remote", "add", "origin", remote)
+	run("push", "-q", "-u", "origin", "main")
+
+	if err := os.WriteFile(filepath.Join(repo, "f.txt"), []byte("hi\nmore\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	run("commit", "-q", "-am", "more")
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(repo); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	var lines []string
+	if err := Push(context.Background(), func(line string) {
+		lines = append(lines, line)
+	}); err != nil {
+		t.Fatalf("Push: %v", err)
+	}
+
+	out, err := exec.Command("git", "--git-dir="+remote, "log", "-1", "--format=%s", "main").Output()
+	if err != nil {
+		t.Fatalf("git log on remote: %v", err)
+	}
+	if got := string(out); got != "more\n" {
+		t.Fatalf("remote main HEAD subject = %q, want %q", got, "more\n")
+	}
+}